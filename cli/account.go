@@ -5,22 +5,27 @@ import (
 	"fmt"
 	"github.com/ethereum/go-ethereum/common"
 	"math/big"
-	"os"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
+type accountsJSON struct {
+	Addresses []string `json:"addresses"`
+}
+
+type accountBalanceJSON struct {
+	Balances []balanceJSON `json:"balances"`
+	Total    *amountJSON   `json:"total,omitempty"`
+}
+
 func (cli *CLI) buildAccountCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "account [new|list|balance]",
 		Short: fmt.Sprintf("Manage %s accounts", cli.blockchain.String()),
 		Args:  cobra.MinimumNArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
-			return
-		},
+		RunE:  cli.unknownCommand,
 	}
 
 	cmd.AddCommand(cli.buildAccountNewCmd())
@@ -36,45 +41,52 @@ func (cli *CLI) buildAccountNewCmd() *cobra.Command {
 		Short:                 "create a new account",
 		Args:                  cobra.MinimumNArgs(0),
 		DisableFlagsInUseLine: true,
-		Run: func(cmd *cobra.Command, args []string) {
+		RunE: func(cmd *cobra.Command, args []string) error {
 			var err error
 			walletPath := cli.walletPath
 			wallet := keystore.NewKeyStore(walletPath,
 				keystore.LightScryptN, keystore.LightScryptP)
 
 			if cli.walletPassword == "" {
-				cli.walletPassword, err = getPassPhrase("Your new account is locked with a password. Please give a password. Do not forget this password.", true)
+				cli.walletPassword, err = cli.getPassPhrase("Your new account is locked with a password. Please give a password. Do not forget this password.", true)
 				if err != nil {
-					fmt.Println("Error: ", err)
-					return
+					return validationErrorf("%v", err)
 				}
 			}
 
 			numOfNew, err := cmd.Flags().GetInt("numOfNew")
 			if err != nil {
-				numOfNew = viper.GetInt("account.numOfNew")
+				numOfNew = cli.v.GetInt("account.numOfNew")
 			}
 			if numOfNew <= 0 {
-				fmt.Printf("number[%d] of new account less then 1\n", numOfNew)
+				cli.printf("number[%d] of new account less then 1\n", numOfNew)
 				numOfNew = 1
 			}
 
 			faucet, _ := cmd.Flags().GetBool("faucet")
 
+			addresses := make([]string, 0, numOfNew)
+			defer func() {
+				if cli.isJSON() {
+					cli.printJSON(accountsJSON{addresses})
+				}
+			}()
 			for i := 0; i < numOfNew; i++ {
 				account, err := wallet.NewAccount(cli.walletPassword)
 				if err != nil {
-					fmt.Println("Account error:", err)
-					return
+					return configErrorf("Account error: %v", err)
 				}
 				if faucet {
-					getFaucet(cli.rpcURL, account.Address.String())
+					cli.getFaucet(account.Address.String())
 				}
-				fmt.Println(account.Address.Hex())
+				cli.println(account.Address.Hex())
+				addresses = append(addresses, account.Address.Hex())
 				if cli.address == "" {
 					cli.address = account.Address.String()
 				}
 			}
+
+			return nil
 		},
 	}
 
@@ -89,18 +101,25 @@ func (cli *CLI) buildAccountListCmd() *cobra.Command {
 		Short:                 "list all accounts in the wallet path",
 		Args:                  cobra.MinimumNArgs(0),
 		DisableFlagsInUseLine: true,
-		Run: func(cmd *cobra.Command, args []string) {
+		RunE: func(cmd *cobra.Command, args []string) error {
 			walletPath := cli.walletPath
 			wallet := keystore.NewKeyStore(walletPath,
 				keystore.LightScryptN, keystore.LightScryptP)
 			if len(wallet.Accounts()) == 0 {
-				fmt.Println("Empty wallet, create account first.")
-				return
+				cli.println("Empty wallet, create account first.")
 			}
 
+			addresses := make([]string, 0, len(wallet.Accounts()))
 			for _, account := range wallet.Accounts() {
-				fmt.Println(account.Address.Hex())
+				cli.println(account.Address.Hex())
+				addresses = append(addresses, account.Address.Hex())
+			}
+
+			if cli.isJSON() {
+				cli.printJSON(accountsJSON{addresses})
 			}
+
+			return nil
 		},
 	}
 
@@ -109,30 +128,29 @@ func (cli *CLI) buildAccountListCmd() *cobra.Command {
 
 func (cli *CLI) buildAccountBalanceCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:                   fmt.Sprintf("balance [-u %s] [-n pending] [-s] [address1] [address2]...", strings.Join(UnitList, "|")),
+		Use:                   fmt.Sprintf("balance [-u %s] [-n pending] [-s] [address1] [address2]...", strings.Join(cli.blockchain.UnitList(), "|")),
 		Short:                 "Get balance of address",
 		Args:                  cobra.MinimumNArgs(0),
 		DisableFlagsInUseLine: true,
-		Run: func(cmd *cobra.Command, args []string) {
-			cli.showBalance(cmd, args, false)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cli.showBalance(cmd, args, false)
 		},
 	}
 
-	cmd.Flags().StringP("unit", "u", "", fmt.Sprintf("unit for balance. %s.", UnitString))
+	cmd.Flags().StringP("unit", "u", "", fmt.Sprintf("unit for balance. %s.", cli.blockchain.UnitString()))
 	cmd.Flags().Bool("safe", false, "enable safe mode to check balance (force use the block 3 block heights less than the latest)")
 	cmd.Flags().StringP("number", "n", "latest", `the integer block number, or the string "latest", "earliest" or "pending"`)
 
 	return cmd
 }
 
-func (cli *CLI) showBalance(cmd *cobra.Command, args []string, showSum bool) {
+func (cli *CLI) showBalance(cmd *cobra.Command, args []string, showSum bool) error {
 	var err error
 
 	unit, _ := cmd.Flags().GetString("unit")
-	if unit != "" && !stringInSlice(unit, UnitList) {
-		fmt.Printf("Unit(%s) for invalid. %s.\n", unit, UnitString)
-		fmt.Fprint(os.Stderr, cmd.UsageString())
-		return
+	if unit != "" && !stringInSlice(unit, cli.blockchain.UnitList()) {
+		fmt.Fprint(cli.stderr, cmd.UsageString())
+		return validationErrorf("Unit(%s) for invalid. %s.", unit, cli.blockchain.UnitString())
 	}
 
 	safe, _ := cmd.Flags().GetBool("safe")
@@ -144,8 +162,7 @@ func (cli *CLI) showBalance(cmd *cobra.Command, args []string, showSum bool) {
 		if cmd.Flags().Changed("number") {
 			numStr, err := cmd.Flags().GetString("number")
 			if err != nil {
-				fmt.Println("Error: arg number get error: ", err)
-				return
+				return validationErrorf("arg number get error: %v", err)
 			}
 			switch numStr {
 			case "pending":
@@ -164,12 +181,10 @@ func (cli *CLI) showBalance(cmd *cobra.Command, args []string, showSum bool) {
 				var ok bool
 				number, ok = number.SetString(numStr, 10)
 				if !ok {
-					fmt.Println("Error: arg number convert to big int error")
-					return
+					return validationErrorf("arg number convert to big int error")
 				}
 				if number.Cmp(big.NewInt(0)) < 0 {
-					fmt.Println("Error: arg number is less than 0")
-					return
+					return validationErrorf("arg number is less than 0")
 				}
 			}
 		}
@@ -179,8 +194,7 @@ func (cli *CLI) showBalance(cmd *cobra.Command, args []string, showSum bool) {
 
 	if len(args) <= 0 {
 		if err := cli.buildWallet(); err != nil {
-			fmt.Println(err)
-			return
+			return err
 		}
 
 		for _, account := range cli.wallet.Accounts() {
@@ -189,13 +203,15 @@ func (cli *CLI) showBalance(cmd *cobra.Command, args []string, showSum bool) {
 
 	} else {
 		for _, addressStr := range args {
+			if !common.IsHexAddress(addressStr) {
+				return validationErrorf("address(%s) invalid", addressStr)
+			}
 			addressList = append(addressList, common.HexToAddress(addressStr))
 		}
 	}
 
 	if err := cli.BuildClient(); err != nil {
-		fmt.Println(err)
-		return
+		return err
 	}
 	ctx := context.Background()
 
@@ -203,20 +219,19 @@ func (cli *CLI) showBalance(cmd *cobra.Command, args []string, showSum bool) {
 	if safe {
 		latestHeader, err := cli.client.HeaderByNumber(ctx, nil)
 		if err != nil {
-			fmt.Println("HeaderByBlock error: ", err)
-			return
+			return rpcErrorf("HeaderByBlock error: %v", err)
 		}
 		if latestHeader == nil {
-			fmt.Println("HeaderByBlock return nil")
-			return
+			return rpcErrorf("HeaderByBlock return nil")
 		}
 		blockNumber = big.NewInt(0).Sub(latestHeader.Number, big.NewInt(3))
-		fmt.Printf("Safe mode enable, check balance at block height %s while the latest is %s\n", blockNumber.String(), latestHeader.Number.String())
+		cli.printf("Safe mode enable, check balance at block height %s while the latest is %s\n", blockNumber.String(), latestHeader.Number.String())
 	} else if !latest && !pending {
 		blockNumber = number
 	}
 
 	balanceSum := big.NewInt(0)
+	balances := make([]balanceJSON, 0, len(addressList))
 	for _, address := range addressList {
 		var balance *big.Int
 		if pending {
@@ -224,18 +239,29 @@ func (cli *CLI) showBalance(cmd *cobra.Command, args []string, showSum bool) {
 		} else {
 			balance, err = cli.client.BalanceAt(ctx, address, blockNumber)
 		}
-		balanceSum.Add(balanceSum, balance)
 		if err != nil {
-			fmt.Println("Balance error:", err)
-			return
+			return rpcErrorf("Balance error: %v", err)
 		}
-		fmt.Printf("Address[%s] Balance[%s]\n", address.Hex(), getWeiAmountTextUnitByUnit(balance, unit))
+		balanceSum.Add(balanceSum, balance)
+		cli.printf("Address[%s] Balance[%s]\n", address.Hex(), cli.blockchain.getWeiAmountTextUnitByUnit(balance, unit))
+		balances = append(balances, balanceJSON{
+			Address: address.Hex(),
+			Balance: newAmountJSON(balance, 18, cli.blockchain.UnitETH()),
+		})
 	}
 
 	if showSum {
-		fmt.Println("Number Of Accounts:", len(addressList))
-		fmt.Println("Total Balance:", getWeiAmountTextUnitByUnit(balanceSum, unit))
+		cli.println("Number Of Accounts:", len(addressList))
+		cli.println("Total Balance:", cli.blockchain.getWeiAmountTextUnitByUnit(balanceSum, unit))
+	}
+
+	if cli.isJSON() {
+		result := accountBalanceJSON{Balances: balances}
+		if showSum {
+			result.Total = newAmountJSON(balanceSum, 18, cli.blockchain.UnitETH())
+		}
+		cli.printJSON(result)
 	}
 
-	return
+	return nil
 }
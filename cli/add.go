@@ -2,7 +2,6 @@ package cli
 
 import (
 	"fmt"
-	"github.com/spf13/viper"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/spf13/cobra"
@@ -14,11 +13,10 @@ func (cli *CLI) buildAddCmd() *cobra.Command {
 		Short:                 "Add custom contract",
 		Args:                  cobra.MinimumNArgs(1),
 		DisableFlagsInUseLine: true,
-		Run: func(cmd *cobra.Command, args []string) {
+		RunE: func(cmd *cobra.Command, args []string) error {
 
 			if !common.IsHexAddress(args[0]) {
-				fmt.Println("Contract address invalid")
-				return
+				return validationErrorf("Contract address invalid")
 			}
 			contractAddress := common.HexToAddress(args[0])
 			cli.contractAddress = contractAddress.String()
@@ -31,46 +29,67 @@ func (cli *CLI) buildAddCmd() *cobra.Command {
 			if symbol == "" {
 				SimpleToken, err := cli.GetSimpleToken()
 				if err != nil {
-					fmt.Println("GetSimpleToken Error: ", err)
-					fmt.Println(cmd.UsageString())
-					return
+					return err
 				}
 				symbol, err = SimpleToken.Symbol(nil)
 				if err != nil {
-					fmt.Println("Get Symbol error, please enter the symbol")
-					return
+					return rpcErrorf("Get Symbol error, please enter the symbol")
 				}
 			}
 
 			// re-check
 			if symbol == "" {
-				fmt.Println("Symbol is empty")
-				return
+				return validationErrorf("Symbol is empty")
 			}
 
-			addressStr := viper.GetString(fmt.Sprintf("Contracts.%s", symbol))
+			addressStr := cli.v.GetString(fmt.Sprintf("Contracts.%s", symbol))
 			if addressStr != "" {
 				if common.HexToAddress(addressStr) != contractAddress {
-					fmt.Printf("This symbol %s has been used by the contract address %s, please choose a new symbol\n", symbol, addressStr)
-					return
+					return validationErrorf("This symbol %s has been used by the contract address %s, please choose a new symbol", symbol, addressStr)
 				}
-				fmt.Println("This contract address has been added before.")
-				return
+				cli.println("This contract address has been added before.")
+				return cli.showAddJSON(symbol, contractAddress, false)
 			}
-			viper.Set(fmt.Sprintf("Contracts.%s", symbol), contractAddress.String())
-			err := viper.WriteConfigAs(cli.config)
+			cli.v.Set(fmt.Sprintf("Contracts.%s", symbol), contractAddress.String())
+			err := cli.v.WriteConfigAs(cli.config)
 			if err != nil {
-				fmt.Println("WriteConfig:", err)
-				return
+				return configErrorf("WriteConfig: %v", err)
 			}
 
-			fmt.Printf("The contract %s(%s) has been added.\n", symbol, contractAddress.String())
+			cli.printf("The contract %s(%s) has been added.\n", symbol, contractAddress.String())
 
-			cli.buildInfoCmd().Run(cmd, args)
+			if cli.isJSON() {
+				return cli.showAddJSON(symbol, contractAddress, true)
+			}
 
-			return
+			return cli.buildInfoCmd().RunE(cmd, nil)
 		},
 	}
 
 	return cmd
 }
+
+type addJSON struct {
+	Symbol          string    `json:"symbol"`
+	ContractAddress string    `json:"contractAddress"`
+	Added           bool      `json:"added"`
+	Info            *infoJSON `json:"info"`
+}
+
+func (cli *CLI) showAddJSON(symbol string, contractAddress common.Address, added bool) error {
+	if !cli.isJSON() {
+		return nil
+	}
+	info, err := cli.getInfoJSON(nil, nil)
+	if err != nil {
+		return err
+	}
+	cli.printJSON(addJSON{
+		Symbol:          symbol,
+		ContractAddress: contractAddress.String(),
+		Added:           added,
+		Info:            info,
+	})
+
+	return nil
+}
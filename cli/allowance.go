@@ -0,0 +1,252 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/newtonproject/tokencommander/token"
+	"github.com/spf13/cobra"
+)
+
+const (
+	allowanceApprove  = "approve"
+	allowanceIncrease = "increase"
+	allowanceDecrease = "decrease"
+)
+
+type allowanceJSON struct {
+	Owner     string      `json:"owner"`
+	Spender   string      `json:"spender"`
+	Allowance *amountJSON `json:"allowance"`
+	Call      bool        `json:"call,omitempty"`
+	Data      string      `json:"data,omitempty"`
+	Tx        *txJSON     `json:"tx,omitempty"`
+}
+
+func (cli *CLI) buildAllowanceCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "allowance [show|approve|increase|decrease]",
+		Short: fmt.Sprintf("Manage the allowances of spenders, only for %s", cli.blockchain.ModeERC20()),
+		Args:  cobra.MinimumNArgs(1),
+		RunE:  cli.unknownCommand,
+	}
+
+	cmd.AddCommand(cli.buildAllowanceShowCmd())
+	cmd.AddCommand(cli.buildAllowanceChangeCmd(allowanceApprove, "set the allowance of spender to amount"))
+	cmd.AddCommand(cli.buildAllowanceChangeCmd(allowanceIncrease, "increase the allowance of spender by amount"))
+	cmd.AddCommand(cli.buildAllowanceChangeCmd(allowanceDecrease, "decrease the allowance of spender by amount"))
+
+	return cmd
+}
+
+func (cli *CLI) buildAllowanceShowCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "show <spender> [--owner ownerAddress]",
+		Short:                 "show the allowance of spender over the tokens of owner",
+		Args:                  cobra.MinimumNArgs(1),
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cli.mode != cli.blockchain.ModeERC20() {
+				return validationErrorf("%v", cli.blockchain.errOnlyERC20())
+			}
+
+			if !common.IsHexAddress(args[0]) {
+				return validationErrorf("illegal spender address %s", args[0])
+			}
+			spender := common.HexToAddress(args[0])
+
+			ownerStr := cli.address
+			if cmd.Flags().Changed("owner") {
+				ownerStr, _ = cmd.Flags().GetString("owner")
+			}
+			if !common.IsHexAddress(ownerStr) {
+				fmt.Fprint(cli.stderr, cmd.UsageString())
+				return validationErrorf("not set owner address or owner address illegal")
+			}
+
+			return cli.showAllowance(context.Background(), common.HexToAddress(ownerStr), spender, nil, nil, false, nil)
+		},
+	}
+
+	cmd.Flags().String("owner", "", "the owner address of the tokens, default is the from address")
+
+	return cmd
+}
+
+func (cli *CLI) buildAllowanceChangeCmd(action, short string) *cobra.Command {
+	use := fmt.Sprintf("%s <spender> <amount> [--nowait]", action)
+	if action == allowanceApprove {
+		use = fmt.Sprintf("%s <spender> <amount> [--call] [--call-data <hex|@file>] [--nowait]", action)
+	}
+
+	cmd := &cobra.Command{
+		Use:                   use,
+		Short:                 short,
+		Args:                  cobra.MinimumNArgs(2),
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cli.mode != cli.blockchain.ModeERC20() {
+				return validationErrorf("%v", cli.blockchain.errOnlyERC20())
+			}
+
+			if !common.IsHexAddress(args[0]) {
+				return validationErrorf("illegal spender address %s", args[0])
+			}
+			spender := common.HexToAddress(args[0])
+
+			if cli.address == "" || !common.IsHexAddress(cli.address) {
+				fmt.Fprint(cli.stderr, cmd.UsageString())
+				return configErrorf("not set from address of owner or from address illegal")
+			}
+			owner := common.HexToAddress(cli.address)
+
+			var call bool
+			var data []byte
+			if action == allowanceApprove {
+				call, _ = cmd.Flags().GetBool("call")
+				if cmd.Flags().Changed("call-data") {
+					dataStr, _ := cmd.Flags().GetString("call-data")
+					var err error
+					data, err = parseCallData(dataStr)
+					if err != nil {
+						return validationErrorf("illegal call data %s: %v", dataStr, err)
+					}
+					call = true
+				}
+			}
+
+			nowait, _ := cmd.Flags().GetBool("nowait")
+			return cli.changeAllowance(action, owner, spender, args[1], nowait, call, data)
+		},
+	}
+
+	if action == allowanceApprove {
+		cmd.Flags().Bool("call", false, "approve by approveAndCall of ERC1363, the spender should implement IERC1363Spender")
+		cmd.Flags().String("call-data", "", "approve by approveAndCall of ERC1363 with the calldata, 0x hex or @file")
+	}
+	cmd.Flags().Bool("nowait", false, "do not wait for tx to be mined")
+
+	return cmd
+}
+
+// changeAllowance changes the allowance of spender over the tokens of owner.
+// If call is set, approve by approveAndCall of ERC1363 with data.
+func (cli *CLI) changeAllowance(action string, owner, spender common.Address, amountStr string, nowait, call bool, data []byte) error {
+	tok, err := cli.GetToken()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+	defer cancel()
+
+	symbol, err := tok.Symbol(ctx)
+	if err != nil {
+		return rpcErrorf("Symbol: Get Symbol Error(%v)", err)
+	}
+	decimals, err := tok.Decimals(ctx)
+	if err != nil {
+		return rpcErrorf("Decimals: Get decimals Error(%v)", err)
+	}
+
+	amount, err := token.ParseAmount(amountStr, decimals)
+	if err != nil {
+		return validationErrorf("%v", err)
+	}
+
+	opts, err := cli.getTransactOpts(owner.String())
+	if err != nil {
+		return err
+	}
+	opts.Context = ctx
+
+	amountText := getAmountTextByWeiWithDecimals(amount, decimals)
+	cli.printf("Try to %s allowance of %s by %s %s for owner %s ...\n",
+		action, spender.String(), amountText, symbol, owner.String())
+	if call {
+		cli.printf("Call the spender with %d bytes data after approve\n", len(data))
+	}
+
+	var tx *types.Transaction
+	switch action {
+	case allowanceApprove:
+		if call {
+			tx, err = tok.ApproveAndCall(opts, spender, amount, data)
+			break
+		}
+		tx, err = tok.Approve(opts, spender, amount)
+	case allowanceIncrease:
+		tx, err = tok.IncreaseAllowance(opts, spender, amount)
+	case allowanceDecrease:
+		tx, err = tok.DecreaseAllowance(opts, spender, amount)
+	default:
+		return validationErrorf("unknown allowance action %s", action)
+	}
+	if err != nil {
+		return tokenErrorf(CategoryRPC, "%s allowance error: %w", action, err)
+	}
+	cli.printf("Succeed submit %s allowance of %s, TxID %s.\n", action, spender.String(), tx.Hash().String())
+
+	var receipt *token.Receipt
+	if !nowait {
+		receipt, err = cli.waitMined(ctx, tx)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := cli.showAllowance(ctx, owner, spender, tx, receipt, call, data); err != nil {
+		return err
+	}
+
+	if receipt != nil && !receipt.Succeeded() {
+		return revertedErrorf("the tx %s is confirmed but status is failed", tx.Hash().String())
+	}
+
+	return nil
+}
+
+// showAllowance shows the current allowance of spender over the tokens of
+// owner, with the tx and calldata which changed it if any
+func (cli *CLI) showAllowance(ctx context.Context, owner, spender common.Address, tx *types.Transaction, receipt *token.Receipt, call bool, data []byte) error {
+	tok, err := cli.GetToken()
+	if err != nil {
+		return err
+	}
+
+	symbol, err := tok.Symbol(ctx)
+	if err != nil {
+		return rpcErrorf("Symbol: Get Symbol Error(%v)", err)
+	}
+	decimals, err := tok.Decimals(ctx)
+	if err != nil {
+		return rpcErrorf("Decimals: Get decimals Error(%v)", err)
+	}
+	allowance, err := tok.Allowance(ctx, owner, spender)
+	if err != nil {
+		return tokenErrorf(CategoryRPC, "Allowance: Get allowance Error(%w)", err)
+	}
+
+	cli.printf("The allowance of spender %s over the tokens of owner %s is %s %s\n",
+		spender.String(), owner.String(), getAmountTextByWeiWithDecimals(allowance, decimals), symbol)
+
+	if cli.isJSON() {
+		result := allowanceJSON{
+			Owner:     owner.String(),
+			Spender:   spender.String(),
+			Allowance: newAmountJSON(allowance, decimals, symbol),
+			Call:      call,
+			Tx:        newTxJSON(tx, receipt),
+		}
+		if len(data) > 0 {
+			result.Data = hexutil.Encode(data)
+		}
+		cli.printJSON(result)
+	}
+
+	return nil
+}
@@ -0,0 +1,13 @@
+package cli
+
+import "testing"
+
+func TestAllowance(t *testing.T) {
+	cli := NewCLI()
+
+	cli.TestCommand("allowance show 0xDC8F76075Db000Fa70fdA3AA2c95d63F22A10a67 --owner 0xeF0b04a14e62434a99C4aF28C6dAb52ba9B1C8F3")
+	cli.TestCommand("allowance approve 0xDC8F76075Db000Fa70fdA3AA2c95d63F22A10a67 100")
+	cli.TestCommand("allowance increase 0xDC8F76075Db000Fa70fdA3AA2c95d63F22A10a67 0.5")
+	cli.TestCommand("allowance decrease 0xDC8F76075Db000Fa70fdA3AA2c95d63F22A10a67 0.5")
+	cli.TestCommand("allowance approve 0xDC8F76075Db000Fa70fdA3AA2c95d63F22A10a67 100 --call-data 0x01")
+}
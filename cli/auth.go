@@ -69,35 +69,52 @@ func NewKeyedTransactor(key *ecdsa.PrivateKey, networkID *big.Int) *bind.Transac
 	}
 }
 
-func NewKeyedTransactorByAccount(wallet *keystore.KeyStore, account accounts.Account, passphrase string, networkID *big.Int) *bind.TransactOpts {
+// NewKeyedTransactorByAccount is a utility method to create a transaction signer
+// which shows the tx in the units of bc to w and unlocks the account of the
+// wallet before signing
+func NewKeyedTransactorByAccount(w io.Writer, bc BlockChain, wallet *keystore.KeyStore, account accounts.Account, passphrase string, networkID *big.Int) *bind.TransactOpts {
+	return keyedTransactorByAccount(w, bc, wallet, account, passphrase, networkID, nil)
+}
+
+func (cli *CLI) newKeyedTransactorByAccount(wallet *keystore.KeyStore, account accounts.Account, passphrase string, networkID *big.Int) *bind.TransactOpts {
+	return keyedTransactorByAccount(cli.logWriter(), cli.blockchain, wallet, account, passphrase, networkID, func(prompt string) (string, error) {
+		return cli.getPassPhrase(prompt, false)
+	})
+}
+
+// keyedTransactorByAccount returns the signer of NewKeyedTransactorByAccount,
+// which asks for the passphrase by getPassPhrase once more if the account
+// is not unlocked by passphrase and getPassPhrase is not nil
+func keyedTransactorByAccount(w io.Writer, bc BlockChain, wallet *keystore.KeyStore, account accounts.Account, passphrase string, networkID *big.Int,
+	getPassPhrase func(prompt string) (string, error)) *bind.TransactOpts {
 	return &bind.TransactOpts{
 		From: account.Address,
 		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
-			fmt.Println("The tx is as follow: ")
-			fmt.Println("\tFrom:", account.Address.String())
+			fmt.Fprintln(w, "The tx is as follow: ")
+			fmt.Fprintln(w, "\tFrom:", account.Address.String())
 			if tx.To() == nil {
-				fmt.Println("\tTo: ContractCreate")
+				fmt.Fprintln(w, "\tTo: ContractCreate")
 			} else {
-				fmt.Println("\tTo:", tx.To().String())
+				fmt.Fprintln(w, "\tTo:", tx.To().String())
 			}
-			fmt.Println("\tValue:", getWeiAmountTextByUnit(tx.Value(), UnitETH))
-			fmt.Println("\tData:", hex.EncodeToString(tx.Data()))
-			fmt.Println("\tNonce:", tx.Nonce())
-			fmt.Println("\tGasPrice:", getWeiAmountTextByUnit(tx.GasPrice(), UnitETH))
-			fmt.Println("\tGasLimit:", tx.Gas())
-			fmt.Println("\tGasFee:", getWeiAmountTextByUnit(big.NewInt(0).Mul(tx.GasPrice(), big.NewInt(0).SetUint64(tx.Gas())), UnitETH))
+			fmt.Fprintln(w, "\tValue:", bc.getWeiAmountTextByUnit(tx.Value(), bc.UnitETH()))
+			fmt.Fprintln(w, "\tData:", hex.EncodeToString(tx.Data()))
+			fmt.Fprintln(w, "\tNonce:", tx.Nonce())
+			fmt.Fprintln(w, "\tGasPrice:", bc.getWeiAmountTextByUnit(tx.GasPrice(), bc.UnitETH()))
+			fmt.Fprintln(w, "\tGasLimit:", tx.Gas())
+			fmt.Fprintln(w, "\tGasFee:", bc.getWeiAmountTextByUnit(big.NewInt(0).Mul(tx.GasPrice(), big.NewInt(0).SetUint64(tx.Gas())), bc.UnitETH()))
 
 			for trials := 0; trials <= 1; trials++ {
 				err := wallet.Unlock(account, passphrase)
 				if err == nil {
 					break
 				}
-				if trials >= 1 {
+				if trials >= 1 || getPassPhrase == nil {
 					return nil, fmt.Errorf("failed to unlock account %s (%v)", account.Address.String(), err)
 
 				}
 				prompt := fmt.Sprintf("Unlocking account %s", account.Address.String())
-				passphrase, _ = getPassPhrase(prompt, false)
+				passphrase, _ = getPassPhrase(prompt)
 			}
 
 			return wallet.SignTx(account, tx, networkID)
@@ -1,8 +1,6 @@
 package cli
 
 import (
-	"fmt"
-
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/spf13/cobra"
 )
@@ -13,40 +11,59 @@ func (cli *CLI) buildBalanceCmd() *cobra.Command {
 		Short:                 "Balance of address on Token",
 		Args:                  cobra.MinimumNArgs(0),
 		DisableFlagsInUseLine: true,
-		Run: func(cmd *cobra.Command, args []string) {
+		RunE: func(cmd *cobra.Command, args []string) error {
 
 			var addressList []common.Address
 			if len(args) > 0 {
 				for i := 0; i < len(args); i++ {
-					if common.IsHexAddress(args[i]) {
-						addressList = append(addressList, common.HexToAddress(args[i]))
+					if !common.IsHexAddress(args[i]) {
+						return validationErrorf("address(%s) invalid", args[i])
 					}
+					addressList = append(addressList, common.HexToAddress(args[i]))
 				}
 			} else {
 				err := cli.buildWallet()
 				if err != nil {
-					fmt.Println("BuildClient error: ", err)
-					return
+					return err
 				}
 				for _, account := range cli.wallet.Accounts() {
 					addressList = append(addressList, account.Address)
 				}
 			}
 
-			for _, address := range addressList {
-				if cli.mode == ModeERC721 {
-					tokens := cli.getTokensOfOwner(address)
-					if tokens != nil && len(tokens) > 0 {
-						fmt.Println(address.String(), cli.balanceOfText(address), tokens)
-						continue
-					}
+			balances, err := cli.getBalances(addressList)
+			if err != nil {
+				return err
+			}
+
+			if cli.isJSON() {
+				cli.printJSON(struct {
+					Balances []balanceJSON `json:"balances"`
+				}{balances})
+				return nil
+			}
+
+			for _, b := range balances {
+				balanceText := b.Balance.Text
+				if cli.mode != cli.blockchain.ModeERC721() {
+					balanceText += " " + b.Balance.Symbol
+				}
+				if len(b.TokenIDs) > 0 {
+					cli.println(b.Address, balanceText, b.TokenIDs)
+					continue
 				}
-				fmt.Println(address.String(), cli.balanceOfText(address))
+				cli.println(b.Address, balanceText)
 			}
 
-			return
+			return nil
 		},
 	}
 
 	return cmd
 }
+
+type balanceJSON struct {
+	Address  string      `json:"address"`
+	Balance  *amountJSON `json:"balance"`
+	TokenIDs []string    `json:"tokenIDs,omitempty"`
+}
@@ -1,76 +1,51 @@
 package cli
 
 import (
-	"fmt"
-	"math/big"
+	"context"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/newtonproject/tokencommander/contracts/ERC20"
-	"github.com/newtonproject/tokencommander/contracts/ERC721"
 )
 
-func (cli *CLI) balanceOf(address common.Address) *big.Int {
-
-	simpleToken, err := cli.GetSimpleToken()
-	if err != nil {
-		fmt.Printf("Balance: GetSimpleToken Error(%v)\n", err)
-		return big.NewInt(0)
-	}
-	balance, err := simpleToken.BalanceOf(nil, address)
+// getBalances returns the token balance of each address, with the tokenIDs
+// owned for NRC7|ERC721
+func (cli *CLI) getBalances(addressList []common.Address) ([]balanceJSON, error) {
+	tok, err := cli.GetToken()
 	if err != nil {
-		fmt.Printf("Balance: BalanceAt Error(%v)\n", err)
-		return big.NewInt(0)
+		return nil, err
 	}
+	ctx := context.Background()
 
-	return balance
-}
-
-func (cli *CLI) balanceOfText(address common.Address) string {
-
-	simpleToken, err := cli.GetSimpleToken()
-	if err != nil {
-		return fmt.Sprintf("GetSimpleToken Error(%v)", err)
-	}
-	balance, err := simpleToken.BalanceOf(nil, address)
-	if err != nil {
-		return fmt.Sprintf("BalanceOf Error(%v)", err)
-	}
-	if cli.mode == ModeERC721 {
-		return balance.String()
-	}
-	decimals, err := simpleToken.(*ERC20.BaseToken).Decimals(nil)
+	decimals, err := tok.Decimals(ctx)
 	if err != nil {
-		return fmt.Sprintf("Decimals: Get Decimals Error(%v)\n", err)
+		return nil, rpcErrorf("Decimals: Get Decimals Error(%v)", err)
 	}
-	symbol, err := simpleToken.Symbol(nil)
+	symbol, err := tok.Symbol(ctx)
 	if err != nil {
-		return fmt.Sprintf("Symbol: Get Symbol Error(%v)\n", err)
+		return nil, rpcErrorf("Symbol: Get Symbol Error(%v)", err)
 	}
 
-	return getAmountTextByWeiWithDecimals(balance, decimals) + " " + symbol
-}
-
-func (cli *CLI) getTokensOfOwner(address common.Address) []*big.Int {
-	if cli.mode != ModeERC721 {
-		return nil
-	}
-	simpleToken, err := cli.GetSimpleToken()
-	if err != nil {
-		return nil // fmt.Sprintf("GetSimpleToken Error(%v)", err)
-	}
-
-	lenBig, err := simpleToken.(*ERC721.NRC7Full).BalanceOf(nil, address)
-	if err != nil {
-		return nil
-	}
-	tokens := make([]*big.Int, lenBig.Uint64())
-	for i := uint64(0); i < lenBig.Uint64(); i++ {
-		token, err := simpleToken.(*ERC721.NRC7Full).TokenOfOwnerByIndex(nil, address, big.NewInt(0).SetUint64(i))
+	balances := make([]balanceJSON, 0, len(addressList))
+	for _, address := range addressList {
+		balance, err := tok.BalanceOf(ctx, address)
 		if err != nil {
-			return nil // fmt.Sprintf("Decimals: Get Decimals Error(%v)\n", err)
+			return nil, rpcErrorf("BalanceOf Error(%v)", err)
+		}
+		b := balanceJSON{
+			Address: address.String(),
+			Balance: newAmountJSON(balance, decimals, symbol),
+		}
+		if cli.mode == cli.blockchain.ModeERC721() {
+			tokens, err := tok.TokensOfOwner(ctx, address)
+			if err != nil {
+				return nil, rpcErrorf("TokensOfOwner: Get tokenIDs of %s Error(%v)", address.String(), err)
+			}
+			b.TokenIDs = make([]string, 0, len(tokens))
+			for _, tokenID := range tokens {
+				b.TokenIDs = append(b.TokenIDs, tokenID.String())
+			}
 		}
-		tokens[i] = big.NewInt(0).Set(token)
+		balances = append(balances, b)
 	}
 
-	return tokens
+	return balances, nil
 }
@@ -4,113 +4,92 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"io"
 	"math/big"
 	"os"
 	"strings"
 
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/newtonproject/tokencommander/contracts/ERC20"
+	"github.com/newtonproject/tokencommander/token"
 	"github.com/spf13/cobra"
 )
 
+type batchPayTxJSON struct {
+	To     string      `json:"to"`
+	Amount *amountJSON `json:"amount"`
+	Tx     *txJSON     `json:"tx"`
+}
+
+type batchPayJSON struct {
+	From         string           `json:"from"`
+	Total        *amountJSON      `json:"total"`
+	Transactions []batchPayTxJSON `json:"transactions"`
+	// GasTotal is the gas fee in WEI, estimated by gas limit if not wait
+	GasTotal string `json:"gasTotal"`
+}
+
 func (cli *CLI) buildBatchPayCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:                   "batchpay <batch.txt>",
 		Aliases:               []string{"batch"},
-		Short:                 fmt.Sprintf("Batch pay base on file <batch.txt>, only support for %s", ModeERC20),
+		Short:                 "Batch pay base on file <batch.txt> of to,amount or to,tokenID rows",
 		Args:                  cobra.MinimumNArgs(1),
 		DisableFlagsInUseLine: true,
-		Run: func(cmd *cobra.Command, args []string) {
-
-			if cli.mode != ModeERC20 {
-				fmt.Printf("Only support for %s\n", ModeERC20)
-				return
-			}
+		RunE: func(cmd *cobra.Command, args []string) error {
 
 			batchFileName := args[0]
 			file, err := os.Open(batchFileName)
 			if err != nil {
-				fmt.Println(err)
-				return
+				return validationErrorf("%v", err)
 			}
 			defer file.Close()
 
 			err = cli.BuildClient()
 			if err != nil {
-				fmt.Println("BuildClient error: ", err)
-				return
+				return err
 			}
 			client := cli.client
+			ctx := context.Background()
 
-			simpleToken, err := cli.GetSimpleToken()
-			if err != nil {
-				fmt.Println("GetSimpleToken Error: ", err)
-				return
-			}
-			erc20, ok := simpleToken.(*ERC20.BaseToken)
-			if !ok {
-				fmt.Printf("Only support for %s\n", ModeERC20)
-				return
-			}
-
-			callOpts := new(bind.CallOpts)
-			callOpts.Pending = true
-
-			decimals, err := erc20.Decimals(callOpts)
-			if err != nil {
-				fmt.Printf("Decimals: Get Decimals Error(%v)\n", err)
-				return
-			}
-			symbol, err := erc20.Symbol(nil)
+			tok, err := cli.GetToken()
 			if err != nil {
-				fmt.Printf("Symbol: Get Symbol Error(%v)\n", err)
-				return
+				return err
 			}
 
 			// check from
 			if cli.address == "" {
-				fmt.Println("Not set from address")
-				return
+				return configErrorf("Not set from address")
 			}
 			if !common.IsHexAddress(cli.address) {
-				fmt.Println("from address not valid hex")
-				return
+				return configErrorf("from address not valid hex")
 			}
 			address := common.HexToAddress(cli.address)
 			if address == (common.Address{}) {
-				fmt.Println("From address not set")
-				return
+				return configErrorf("From address not set")
 			}
 			wallet := keystore.NewKeyStore(cli.walletPath,
 				keystore.StandardScryptN, keystore.StandardScryptP)
 			if !wallet.HasAddress(address) {
-				fmt.Println("From address not in wallet")
-				return
+				return configErrorf("From address not in wallet")
 			}
 
-			ctx := context.Background()
-
 			chainID, err := client.NetworkID(ctx)
 			if err != nil {
-				fmt.Println(err)
-				return
+				return rpcErrorf("NetworkID Error: %v", err)
 			}
 
 			gasPrice := big.NewInt(0)
 			if cmd.Flags().Changed("price") {
 				price, err := cmd.Flags().GetUint64("price")
 				if err != nil {
-					fmt.Println("price get error: ", err)
-					return
+					return validationErrorf("price get error: %v", err)
 				}
 				gasPrice = big.NewInt(0).SetUint64(price)
 			} else {
 				gasPrice, err = client.SuggestGasPrice(ctx)
 				if err != nil {
-					fmt.Println("SuggestGasPrice error: ", err)
-					return
+					return rpcErrorf("SuggestGasPrice error: %v", err)
 				}
 			}
 
@@ -118,141 +97,274 @@ func (cli *CLI) buildBatchPayCmd() *cobra.Command {
 			if cmd.Flags().Changed("nonce") {
 				nonce, err = cmd.Flags().GetUint64("nonce")
 				if err != nil {
-					fmt.Println("nonce get error: ", err)
-					return
+					return validationErrorf("nonce get error: %v", err)
 				}
 			} else {
 				nonce, err = client.PendingNonceAt(ctx, address)
 				if err != nil {
-					fmt.Println("PendingNonceAt error: ", err)
-					return
+					return rpcErrorf("PendingNonceAt error: %v", err)
 				}
 			}
 
-			type pay struct {
-				to     common.Address
-				amount *big.Int
+			wait, _ := cmd.Flags().GetBool("wait")
+
+			if cli.mode == cli.blockchain.ModeERC721() {
+				return cli.batchPayNFT(ctx, tok, file, address, chainID, gasPrice, nonce, wait)
+			}
+
+			decimals, err := tok.Decimals(ctx)
+			if err != nil {
+				return rpcErrorf("Decimals: Get Decimals Error(%v)", err)
+			}
+			symbol, err := tok.Symbol(ctx)
+			if err != nil {
+				return rpcErrorf("Symbol: Get Symbol Error(%v)", err)
 			}
 
-			batchList := make([]pay, 0)
+			batchList := make([]token.Payment, 0)
 			scanner := bufio.NewScanner(file)
 			for scanner.Scan() {
 				text := scanner.Text()
 				l := strings.Split(text, ",")
 				if len(l) != 2 {
-					fmt.Println("parse error: ", text)
-					return
+					return validationErrorf("parse error: %s", text)
 				}
 
-				var to common.Address
-				if common.IsHexAddress(l[0]) {
-					to = common.HexToAddress(l[0])
-				} else {
-					if cli.blockchain != NewChain {
-						fmt.Println("Convert address error: ", l[0])
-						return
-					}
-					to, err = newToAddress(chainID.Bytes(), l[0])
-					if err != nil {
-						fmt.Println("NewChain: address is invalid hex address or convert from NEW Address to hex error: ", l[0])
-						return
-					}
+				to, err := cli.parseBatchAddress(chainID, l[0])
+				if err != nil {
+					return err
 				}
 				if to == (common.Address{}) {
-					fmt.Println("Warning: to address is zero: ", l[0])
+					cli.println("Warning: to address is zero: ", l[0])
 				}
 
 				amount, ok := getWeiAmountWeiByStringWithDecimals(l[1], 10, decimals)
 				if !ok {
-					fmt.Printf("Amount: convert (%s) from string to amount with decimals(%d) error\n", l[1], decimals)
-					return
+					return validationErrorf("Amount: convert (%s) from string to amount with decimals(%d) error", l[1], decimals)
 				}
 
-				batchList = append(batchList, pay{
-					to:     to,
-					amount: amount})
+				batchList = append(batchList, token.Payment{
+					To:     to,
+					Amount: amount})
 
 			}
 
-			fmt.Println("Please confirm the transactions below:")
-			totalAmount := big.NewInt(0)
+			cli.println("Please confirm the transactions below:")
 			for _, b := range batchList {
-				fmt.Printf("%s,%s\n", b.to.String(),
-					getAmountTextByWeiWithDecimals(b.amount, decimals))
-
-				// total
-				totalAmount.Add(totalAmount, b.amount)
-			}
-			fmt.Println("Number of transactions:", len(batchList))
-
-			if totalAmount.Cmp(big.NewInt(0)) <= 0 {
-				fmt.Println("Total pay amount is zero")
-				return
+				cli.printf("%s,%s\n", b.To.String(),
+					getAmountTextByWeiWithDecimals(b.Amount, decimals))
 			}
+			cli.println("Number of transactions:", len(batchList))
 
-			balance, err := erc20.BalanceOf(callOpts, address)
+			totalAmount, err := tok.CheckBatch(ctx, address, batchList)
 			if err != nil {
-				fmt.Println(err)
-				return
+				return tokenErrorf(CategoryRPC, "%w", err)
 			}
 
-			if balance.Cmp(totalAmount) < 0 {
-				fmt.Println("Error: Insufficient funds")
-				return
-			}
-
-			fmt.Println("Total pay amount:", getAmountTextByWeiWithDecimals(totalAmount, decimals), symbol)
+			cli.println("Total pay amount:", getAmountTextByWeiWithDecimals(totalAmount, decimals), symbol)
 
 			opts, err := cli.getBatchTransactOpts(address.String())
 			if err != nil {
-				fmt.Println("GetTransactOpts: ", err)
-				return
+				return err
 			}
 			opts.Context = ctx
 			opts.GasPrice = gasPrice
 
-			wait, _ := cmd.Flags().GetBool("wait")
 			gasTotal := big.NewInt(0)
-			for _, b := range batchList {
-				to := b.to
-				amount := b.amount
-				opts.Nonce = big.NewInt(0).SetUint64(nonce)
-				tx, err := erc20.Transfer(opts, to, amount)
-				if err != nil {
-					fmt.Println(err)
+			failed := 0
+			results, batchErr := tok.BatchTransfer(opts, batchList, nonce, wait, func(r *token.BatchResult) {
+				if r.Receipt == nil {
+					cli.printf("Succeed broadcast pay %s %s to %s from %s with nonce %d, TxID %s.\n",
+						getAmountTextByWeiWithDecimals(r.Amount, decimals), symbol,
+						r.To.String(), address.String(), r.Nonce, r.Tx.Hash().String())
+					if !wait {
+						gasTotal.Add(gasTotal, big.NewInt(0).Mul(r.Tx.GasPrice(), big.NewInt(0).SetUint64(r.Tx.Gas())))
+					}
 					return
 				}
 
-				fmt.Printf("Succeed broadcast pay %s %s to %s from %s with nonce %d, TxID %s.\n",
-					getAmountTextByWeiWithDecimals(amount, decimals), symbol,
-					to.String(), address.String(), nonce, tx.Hash().String())
-
-				if wait {
-					txr, err := bind.WaitMined(ctx, client, tx)
-					if err != nil {
-						fmt.Println(err)
-						return
-					}
-					if txr.Status == 1 {
-						fmt.Printf("Succeed mined txID %s.\n", txr.TxHash.String())
-					} else {
-						fmt.Printf("Succeed mined txID %s but status failed.\n", txr.TxHash.String())
-					}
-					gasTotal.Add(gasTotal, big.NewInt(0).Mul(tx.GasPrice(), big.NewInt(0).SetUint64(txr.GasUsed)))
+				if r.Receipt.Succeeded() {
+					cli.printf("Succeed mined txID %s.\n", r.Receipt.TxHash.String())
 				} else {
-					gasTotal.Add(gasTotal, big.NewInt(0).Mul(tx.GasPrice(), big.NewInt(0).SetUint64(tx.Gas())))
+					cli.printf("Succeed mined txID %s but status failed.\n", r.Receipt.TxHash.String())
+					failed++
+				}
+				gasTotal.Add(gasTotal, r.Receipt.GasFee)
+			})
+			if batchErr != nil {
+				batchErr = tokenErrorf(CategoryRPC, "%w", batchErr)
+				cli.printf("Broadcast %d of %d transactions: %v\n", len(results), len(batchList), batchErr)
+			}
+
+			cli.printf("Total Gas is: %s %s\n", cli.blockchain.getWeiAmountTextByUnit(gasTotal, cli.blockchain.UnitETH()), cli.blockchain.UnitETH())
+
+			if cli.isJSON() {
+				result := batchPayJSON{
+					From:         address.String(),
+					Total:        newAmountJSON(totalAmount, decimals, symbol),
+					Transactions: make([]batchPayTxJSON, 0, len(results)),
+					GasTotal:     gasTotal.String(),
 				}
+				for _, r := range results {
+					result.Transactions = append(result.Transactions, batchPayTxJSON{
+						To:     r.To.String(),
+						Amount: newAmountJSON(r.Amount, decimals, symbol),
+						Tx:     newTxJSON(r.Tx, r.Receipt),
+					})
+				}
+				cli.printJSON(result)
+			}
 
-				nonce++
+			if batchErr != nil {
+				return batchErr
+			}
+			if failed > 0 {
+				return revertedErrorf("%d of %d transactions mined but status failed", failed, len(results))
 			}
 
-			fmt.Printf("Total Gas is: %s %s\n", getWeiAmountTextByUnit(gasTotal, UnitETH), UnitETH)
+			return nil
 		},
 	}
 
-	cmd.Flags().Uint64P("price", "p", 1, fmt.Sprintf("the gasPrice used for each paid gas (unit in %s)", UnitWEI))
+	cmd.Flags().Uint64P("price", "p", 1, fmt.Sprintf("the gasPrice used for each paid gas (unit in %s)", cli.blockchain.UnitWEI()))
 	cmd.Flags().Uint64P("nonce", "n", 0, "the number of nonce to start")
 	cmd.Flags().Bool("wait", false, "wait for transaction to mined")
 
 	return cmd
 }
+
+type batchPayNFTTxJSON struct {
+	To      string  `json:"to"`
+	TokenID string  `json:"tokenID"`
+	Tx      *txJSON `json:"tx"`
+}
+
+type batchPayNFTJSON struct {
+	From         string              `json:"from"`
+	Transactions []batchPayNFTTxJSON `json:"transactions"`
+	// GasTotal is the gas fee in WEI, estimated by gas limit if not wait
+	GasTotal string `json:"gasTotal"`
+}
+
+// parseBatchAddress parses the hex address, or NEW address on NewChain, of
+// a batch file
+func (cli *CLI) parseBatchAddress(chainID *big.Int, s string) (common.Address, error) {
+	if common.IsHexAddress(s) {
+		return common.HexToAddress(s), nil
+	}
+	if cli.blockchain != NewChain {
+		return common.Address{}, validationErrorf("Convert address error: %s", s)
+	}
+	to, err := newToAddress(chainID.Bytes(), s)
+	if err != nil {
+		return common.Address{}, validationErrorf("NewChain: address is invalid hex address or convert from NEW Address to hex error: %s", s)
+	}
+	return to, nil
+}
+
+// batchPayNFT pays the tokenIDs of the to,tokenID rows of file from address.
+// The duplicate tokenIDs and the ownership of every tokenID are checked
+// before any tx is signed.
+func (cli *CLI) batchPayNFT(ctx context.Context, tok *token.Token, file io.Reader, address common.Address,
+	chainID, gasPrice *big.Int, nonce uint64, wait bool) error {
+	symbol, err := tok.Symbol(ctx)
+	if err != nil {
+		return rpcErrorf("Symbol: Get Symbol Error(%v)", err)
+	}
+
+	batchList := make([]token.NFTPayment, 0)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		text := scanner.Text()
+		l := strings.Split(text, ",")
+		if len(l) != 2 {
+			return validationErrorf("parse error: %s", text)
+		}
+
+		to, err := cli.parseBatchAddress(chainID, strings.TrimSpace(l[0]))
+		if err != nil {
+			return err
+		}
+		tokenID, err := parseTokenID(strings.TrimSpace(l[1]))
+		if err != nil {
+			return err
+		}
+
+		batchList = append(batchList, token.NFTPayment{
+			To:      to,
+			TokenID: tokenID})
+	}
+	if err := scanner.Err(); err != nil {
+		return validationErrorf("read batch file error: %v", err)
+	}
+
+	cli.println("Please confirm the transactions below:")
+	for _, b := range batchList {
+		cli.printf("%s,%s\n", b.To.String(), b.TokenID.String())
+	}
+	cli.println("Number of transactions:", len(batchList))
+
+	if err := tok.CheckBatchNFT(ctx, address, batchList); err != nil {
+		return tokenErrorf(CategoryRPC, "%w", err)
+	}
+	cli.printf("Total pay %d tokenIDs of %s\n", len(batchList), symbol)
+
+	opts, err := cli.getBatchTransactOpts(address.String())
+	if err != nil {
+		return err
+	}
+	opts.Context = ctx
+	opts.GasPrice = gasPrice
+
+	gasTotal := big.NewInt(0)
+	failed := 0
+	results, batchErr := tok.BatchTransferNFT(opts, batchList, nonce, wait, func(r *token.BatchNFTResult) {
+		if r.Receipt == nil {
+			cli.printf("Succeed broadcast pay %s tokenID %s to %s from %s with nonce %d, TxID %s.\n",
+				symbol, r.TokenID.String(), r.To.String(), address.String(), r.Nonce, r.Tx.Hash().String())
+			if !wait {
+				gasTotal.Add(gasTotal, big.NewInt(0).Mul(r.Tx.GasPrice(), big.NewInt(0).SetUint64(r.Tx.Gas())))
+			}
+			return
+		}
+
+		if r.Receipt.Succeeded() {
+			cli.printf("Succeed mined txID %s.\n", r.Receipt.TxHash.String())
+		} else {
+			cli.printf("Succeed mined txID %s but status failed.\n", r.Receipt.TxHash.String())
+			failed++
+		}
+		gasTotal.Add(gasTotal, r.Receipt.GasFee)
+	})
+	if batchErr != nil {
+		batchErr = tokenErrorf(CategoryRPC, "%w", batchErr)
+		cli.printf("Broadcast %d of %d transactions: %v\n", len(results), len(batchList), batchErr)
+	}
+
+	cli.printf("Total Gas is: %s %s\n", cli.blockchain.getWeiAmountTextByUnit(gasTotal, cli.blockchain.UnitETH()), cli.blockchain.UnitETH())
+
+	if cli.isJSON() {
+		result := batchPayNFTJSON{
+			From:         address.String(),
+			Transactions: make([]batchPayNFTTxJSON, 0, len(results)),
+			GasTotal:     gasTotal.String(),
+		}
+		for _, r := range results {
+			result.Transactions = append(result.Transactions, batchPayNFTTxJSON{
+				To:      r.To.String(),
+				TokenID: r.TokenID.String(),
+				Tx:      newTxJSON(r.Tx, r.Receipt),
+			})
+		}
+		cli.printJSON(result)
+	}
+
+	if batchErr != nil {
+		return batchErr
+	}
+	if failed > 0 {
+		return revertedErrorf("%d of %d transactions mined but status failed", failed, len(results))
+	}
+
+	return nil
+}
@@ -0,0 +1,10 @@
+package cli
+
+import "testing"
+
+func TestBatchPay(t *testing.T) {
+	cli := NewCLI()
+
+	cli.TestCommand("batchpay batch.txt")
+	cli.TestCommand("batchpay nft.txt --mode NRC7 --wait")
+}
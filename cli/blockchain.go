@@ -67,62 +67,67 @@ func getBlockChain() (BlockChain, error) {
 	return UnknownChain, nil
 }
 
-func (bc BlockChain) Init() {
-	InitRPCUrl(bc)
-	InitUnit(bc)
-	InitERC(bc)
+// DefaultRPCURL returns the default json rpc url of the chain
+func (bc BlockChain) DefaultRPCURL() string {
+	if bc == NewChain {
+		return defaultNEWRPCURL
+	}
+	return defaultETHRPCUrl
 }
 
-// Unit
-var (
-	UnitETH = "ETH"
-	UnitWEI = "WEI"
-
-	// UnitList is array for Unit string
-	// UnitList = []string{"Wei", "Ada", "Babbage", "Shannon", "Szabo", "Finney", "Ether", "Einstein", "Douglas", "Gwei"}
-	UnitList []string
-
-	// UnitString is for Unit string
-	// UnitString = "Available unit: Wei, Ada, Babbage, Shannon, Szabo, Finney, Ether, Einstein, Douglas, Gwei"
-	UnitString string
-)
-
-func InitUnit(bc BlockChain) {
+// UnitETH returns the name of the base unit of the chain, such as ETH
+func (bc BlockChain) UnitETH() string {
 	if bc == NewChain {
-		UnitETH = "NEW"
-		UnitWEI = "ISAAC"
-
+		return "NEW"
 	}
+	return "ETH"
+}
 
-	UnitList = []string{UnitETH, UnitWEI}
-	UnitString = fmt.Sprintf("Available unit: %s", strings.Join(UnitList, ","))
+// UnitWEI returns the name of the smallest unit of the chain, such as WEI
+func (bc BlockChain) UnitWEI() string {
+	if bc == NewChain {
+		return "ISAAC"
+	}
+	return "WEI"
 }
 
-var (
-	// ModeERC20 https://github.com/ethereum/EIPs/blob/master/EIPS/eip-20.md
-	ModeERC20 = "ERC20"
-	// ModeERC721 https://github.com/ethereum/EIPs/blob/master/EIPS/eip-721.md
-	ModeERC721 = "ERC721"
+// UnitList returns the available units of the chain
+func (bc BlockChain) UnitList() []string {
+	return []string{bc.UnitETH(), bc.UnitWEI()}
+}
 
-	// UnitList is array for Unit string
-	// UnitList = []string{"Wei", "Ada", "Babbage", "Shannon", "Szabo", "Finney", "Ether", "Einstein", "Douglas", "Gwei"}
-	ModeERCList []string
-)
+// UnitString returns the available units of the chain for help messages
+func (bc BlockChain) UnitString() string {
+	return fmt.Sprintf("Available unit: %s", strings.Join(bc.UnitList(), ","))
+}
 
-var (
-	errOnlyERC20  error
-	errOnlyERC721 error
-)
+// ModeERC20 returns the name of fungible token standard of the chain,
+// https://github.com/ethereum/EIPs/blob/master/EIPS/eip-20.md
+func (bc BlockChain) ModeERC20() string {
+	if bc == NewChain {
+		return "NRC6"
+	}
+	return "ERC20"
+}
 
-func InitERC(bc BlockChain) {
+// ModeERC721 returns the name of non-fungible token standard of the chain,
+// https://github.com/ethereum/EIPs/blob/master/EIPS/eip-721.md
+func (bc BlockChain) ModeERC721() string {
 	if bc == NewChain {
-		ModeERC20 = "NRC6"
-		ModeERC721 = "NRC7"
+		return "NRC7"
 	}
+	return "ERC721"
+}
 
-	ModeERCList = []string{ModeERC20, ModeERC721}
+// ModeERCList returns the available token modes of the chain
+func (bc BlockChain) ModeERCList() []string {
+	return []string{bc.ModeERC20(), bc.ModeERC721()}
+}
+
+func (bc BlockChain) errOnlyERC20() error {
+	return fmt.Errorf("only %s support", bc.ModeERC20())
+}
 
-	// error
-	errOnlyERC20 = fmt.Errorf("only %s support", ModeERC20)
-	errOnlyERC721 = fmt.Errorf("only %s support", ModeERC721)
+func (bc BlockChain) errOnlyERC721() error {
+	return fmt.Errorf("only %s support", bc.ModeERC721())
 }
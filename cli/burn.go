@@ -0,0 +1,164 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/newtonproject/tokencommander/token"
+	"github.com/spf13/cobra"
+)
+
+type burnJSON struct {
+	From        string      `json:"from"`
+	Owner       string      `json:"owner,omitempty"`
+	Amount      *amountJSON `json:"amount,omitempty"`
+	TokenID     string      `json:"tokenID,omitempty"`
+	TotalSupply *amountJSON `json:"totalSupply,omitempty"`
+	Tx          *txJSON     `json:"tx"`
+}
+
+func (cli *CLI) buildBurnCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "burn <amount|tokenID> [--from-account ownerAddress] [--nowait]",
+		Short:                 "Burn token amount or tokenID",
+		Args:                  cobra.MinimumNArgs(1),
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			fromAddressStr := cli.v.GetString("from")
+			if fromAddressStr == "" || !common.IsHexAddress(fromAddressStr) {
+				fmt.Fprint(cli.stderr, cmd.UsageString())
+				return validationErrorf("not set from address of owner or from address illegal")
+			}
+			fromAddress := common.HexToAddress(fromAddressStr)
+
+			var ownerAddress *common.Address
+			if cmd.Flags().Changed("from-account") {
+				ownerAddressStr, _ := cmd.Flags().GetString("from-account")
+				if !common.IsHexAddress(ownerAddressStr) {
+					return validationErrorf("illegal owner address %s", ownerAddressStr)
+				}
+				owner := common.HexToAddress(ownerAddressStr)
+				ownerAddress = &owner
+			}
+
+			nowait, _ := cmd.Flags().GetBool("nowait")
+			return cli.burn(fromAddress, ownerAddress, args[0], nowait)
+		},
+	}
+
+	cmd.Flags().String("from-account", "", "burn the tokens of owner which the from address is allowed or approved to spend")
+	cmd.Flags().Bool("nowait", false, "do not wait for tx to be mined")
+
+	return cmd
+}
+
+// burn burns the amount or tokenID of ownerAddress, which is the fromAddress
+// for NRC6|ERC20 and the owner of the tokenID for NRC7|ERC721 if nil
+func (cli *CLI) burn(fromAddress common.Address, ownerAddress *common.Address, amountStr string, nowait bool) error {
+	tok, err := cli.GetToken()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+	defer cancel()
+
+	symbol, err := tok.Symbol(ctx)
+	if err != nil {
+		return rpcErrorf("Symbol: Get Symbol Error(%v)", err)
+	}
+	decimals, err := tok.Decimals(ctx)
+	if err != nil {
+		return rpcErrorf("Decimals: Get decimals Error(%v)", err)
+	}
+
+	amount, err := token.ParseAmount(amountStr, decimals)
+	if err != nil {
+		return validationErrorf("%v", err)
+	}
+	if cli.mode != cli.blockchain.ModeERC721() && amount.Sign() == 0 {
+		return validationErrorf("the amount to burn should be greater than 0")
+	}
+
+	// refuse before unlocking the wallet if the collection is paused
+	if err := tok.CheckNotPaused(ctx); err != nil {
+		return tokenErrorf(CategoryRPC, "%w", err)
+	}
+
+	opts, err := cli.getTransactOpts(fromAddress.String())
+	if err != nil {
+		return err
+	}
+	opts.Context = ctx
+
+	var onBehalf string
+	if ownerAddress != nil && *ownerAddress != fromAddress {
+		onBehalf = " on behalf of " + ownerAddress.String()
+	}
+	if cli.mode == cli.blockchain.ModeERC721() {
+		cli.printf("Try to burn tokenID %s by %s%s ...\n", amount, fromAddress.String(), onBehalf)
+	} else {
+		cli.printf("Try to burn %s %s by %s%s ...\n",
+			getAmountTextByWeiWithDecimals(amount, decimals), symbol, fromAddress.String(), onBehalf)
+	}
+
+	var tx *types.Transaction
+	if ownerAddress != nil {
+		tx, err = tok.BurnFrom(opts, *ownerAddress, amount)
+	} else {
+		tx, err = tok.Burn(opts, amount)
+	}
+	if err != nil {
+		return tokenErrorf(CategoryRPC, "Burn error: %w", err)
+	}
+	cli.printf("Succeed submit burn, TxID %s.\n", tx.Hash().String())
+
+	result := burnJSON{
+		From: fromAddress.String(),
+		Tx:   newTxJSON(tx, nil),
+	}
+	if onBehalf != "" {
+		result.Owner = ownerAddress.String()
+	}
+	if cli.mode == cli.blockchain.ModeERC721() {
+		result.TokenID = amount.String()
+	} else {
+		result.Amount = newAmountJSON(amount, decimals, symbol)
+	}
+
+	var receipt *token.Receipt
+	if !nowait {
+		receipt, err = cli.waitMined(ctx, tx)
+		if err != nil {
+			return err
+		}
+		result.Tx = newTxJSON(tx, receipt)
+
+		if receipt.Succeeded() {
+			totalSupply, err := tok.TotalSupply(ctx)
+			if err != nil {
+				return rpcErrorf("TotalSupply: Get totalSupply Error(%v)", err)
+			}
+			result.TotalSupply = newAmountJSON(totalSupply, decimals, symbol)
+			if cli.mode == cli.blockchain.ModeERC721() {
+				cli.printf("The total supply is %s now\n", totalSupply.String())
+			} else {
+				cli.printf("The total supply is %s %s now\n", result.TotalSupply.Text, symbol)
+			}
+		}
+	}
+
+	if cli.isJSON() {
+		cli.printJSON(result)
+	}
+
+	if receipt != nil && !receipt.Succeeded() {
+		return revertedErrorf("the tx %s is confirmed but status is failed", tx.Hash().String())
+	}
+
+	return nil
+}
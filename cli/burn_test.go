@@ -0,0 +1,11 @@
+package cli
+
+import "testing"
+
+func TestBurn(t *testing.T) {
+	cli := NewCLI()
+
+	cli.TestCommand("burn 1.5")
+	cli.TestCommand("burn 10 --from-account 0xeF0b04a14e62434a99C4aF28C6dAb52ba9B1C8F3")
+	cli.TestCommand("burn 10 --mode NRC7")
+}
@@ -1,21 +1,24 @@
 package cli
 
 import (
+	"bufio"
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
-	"github.com/newtonproject/tokencommander/contracts/ERC20"
-	"github.com/newtonproject/tokencommander/contracts/ERC721"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/newtonproject/tokencommander/token"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -25,8 +28,9 @@ var (
 	buildDate   string
 )
 
-// CLI represents a command-line interface. This class is
-// not threadsafe.
+// CLI represents a command-line interface. The CLI keeps its
+// state in the instance and writes to its own writers, so different
+// instances can run concurrently.
 type CLI struct {
 	Name       string
 	rootCmd    *cobra.Command
@@ -38,14 +42,25 @@ type CLI struct {
 	contractAddress string
 	localSymbol     string
 	client          *ethclient.Client
+	rpcClient       *rpc.Client
 	wallet          *keystore.KeyStore
 	account         accounts.Account
 	SimpleToken     SimpleToken
+	token           *token.Token
 	walletPassword  string
 	address         string
 	mode            string
+	output          string
+	jsonPrinted     bool
 
 	blockchain BlockChain
+
+	v      *viper.Viper
+	stdout io.Writer
+	stderr io.Writer
+	stdin  io.Reader
+	reader *bufio.Reader
+	mu     sync.Mutex
 }
 
 // NewCLI returns an initialized CLI
@@ -64,9 +79,6 @@ func NewCLI() *CLI {
 	}
 	version = fmt.Sprintf("%s-%s", version, bc.String())
 
-	// init unit
-	bc.Init()
-
 	cli := &CLI{
 		Name:       filepath.Base(os.Args[0]), // "TokenCommander"
 		rootCmd:    nil,
@@ -79,9 +91,13 @@ func NewCLI() *CLI {
 		client:          nil,
 		SimpleToken:     nil,
 		walletPassword:  "",
-		mode:            ModeERC20,
+		mode:            bc.ModeERC20(),
+		output:          outputText,
 		blockchain:      bc,
+		v:               viper.New(),
 	}
+	cli.SetOutput(os.Stdout, os.Stderr)
+	cli.SetInput(os.Stdin)
 
 	cli.buildRootCmd()
 	return cli
@@ -91,10 +107,11 @@ func NewCLI() *CLI {
 func (cli *CLI) BuildClient() error {
 	var err error
 	if cli.client == nil {
-		cli.client, err = ethclient.Dial(cli.rpcURL)
+		cli.rpcClient, err = rpc.Dial(cli.rpcURL)
 		if err != nil {
-			return fmt.Errorf("Failed to connect to the NewChain client: %v", err)
+			return rpcErrorf("Failed to connect to the NewChain client: %v", err)
 		}
+		cli.client = ethclient.NewClient(cli.rpcClient)
 	}
 	return nil
 }
@@ -102,37 +119,51 @@ func (cli *CLI) BuildClient() error {
 // BuildSimpleToken BuildClient
 func (cli *CLI) buildSimpleToken() (SimpleToken, error) {
 	var err error
-	if cli.client == nil {
-		cli.BuildClient()
+	if err = cli.BuildClient(); err != nil {
+		return nil, err
 	}
 
 	symbol := cli.localSymbol
 	if symbol != "" {
-		addressStr := viper.GetString(fmt.Sprintf("Contracts.%s", symbol))
+		addressStr := cli.v.GetString(fmt.Sprintf("Contracts.%s", symbol))
 		if addressStr == "" {
-			return nil, fmt.Errorf("contract address of symbol %s not set", symbol)
+			return nil, configErrorf("contract address of symbol %s not set", symbol)
 		}
 		if !common.IsHexAddress(addressStr) {
-			return nil, fmt.Errorf("contract address from symbol %s invalid", symbol)
+			return nil, configErrorf("contract address from symbol %s invalid", symbol)
 		}
 		cli.contractAddress = addressStr
 	}
 
 	if !common.IsHexAddress(cli.contractAddress) {
-		return nil, fmt.Errorf("contract address is invalid")
+		return nil, configErrorf("contract address is invalid")
 	}
 
-	if cli.mode == ModeERC721 {
-		cli.SimpleToken, err = ERC721.NewNRC7Full(common.HexToAddress(cli.contractAddress), cli.client)
-	} else {
-		cli.SimpleToken, err = ERC20.NewBaseToken(common.HexToAddress(cli.contractAddress), cli.client)
-	}
+	cli.token, err = token.New(common.HexToAddress(cli.contractAddress), cli.tokenKind(), cli.client)
 	if err != nil {
-		return nil, fmt.Errorf("NewSimpleToken Error(%v)", err)
+		return nil, err
 	}
+	cli.SimpleToken = cli.token.Contract().(SimpleToken)
 	return cli.SimpleToken, nil
 }
 
+func (cli *CLI) tokenKind() token.Kind {
+	if cli.mode == cli.blockchain.ModeERC721() {
+		return token.NonFungible
+	}
+	return token.Fungible
+}
+
+// GetToken returns the token SDK bound to the contract of the CLI
+func (cli *CLI) GetToken() (*token.Token, error) {
+	if cli.token == nil {
+		if _, err := cli.buildSimpleToken(); err != nil {
+			return nil, err
+		}
+	}
+	return cli.token, nil
+}
+
 // GetSimpleToken GetSimpleToken
 func (cli *CLI) GetSimpleToken() (SimpleToken, error) {
 	if cli.SimpleToken == nil {
@@ -146,7 +177,7 @@ func (cli *CLI) buildWallet() error {
 		cli.wallet = keystore.NewKeyStore(cli.walletPath,
 			keystore.LightScryptN, keystore.LightScryptP)
 		if len(cli.wallet.Accounts()) == 0 {
-			return fmt.Errorf("Empty wallet, create account first")
+			return configErrorf("Empty wallet, create account first")
 		}
 	}
 
@@ -164,12 +195,12 @@ func (cli *CLI) buildAccount(address string) error {
 		if common.IsHexAddress(cli.address) {
 			address = cli.address
 		} else {
-			return fmt.Errorf("Error: address(%s) invalid", address)
+			return validationErrorf("address(%s) invalid", address)
 		}
 	}
 	cli.account, err = cli.wallet.Find(accounts.Account{Address: common.HexToAddress(address)})
 	if err != nil {
-		return fmt.Errorf("Error: Can not get the keystore file of address %s", address)
+		return configErrorf("Can not get the keystore file of address %s", address)
 	}
 	cli.address = address
 
@@ -182,14 +213,15 @@ func (cli *CLI) getTransactOpts(address string) (*bind.TransactOpts, error) {
 		return nil, err
 	}
 
-	cli.BuildClient()
+	if err := cli.BuildClient(); err != nil {
+		return nil, err
+	}
 	networkID, err := cli.client.NetworkID(context.Background())
 	if err != nil {
-		fmt.Println("NetworkID Error: ", err)
-		return nil, err
+		return nil, rpcErrorf("NetworkID Error: %v", err)
 	}
 
-	opts := NewKeyedTransactorByAccount(cli.wallet, cli.account, cli.walletPassword, networkID)
+	opts := cli.newKeyedTransactorByAccount(cli.wallet, cli.account, cli.walletPassword, networkID)
 	return opts, nil
 }
 
@@ -199,11 +231,12 @@ func (cli *CLI) getBatchTransactOpts(address string) (*bind.TransactOpts, error)
 		return nil, err
 	}
 
-	cli.BuildClient()
+	if err := cli.BuildClient(); err != nil {
+		return nil, err
+	}
 	networkID, err := cli.client.NetworkID(context.Background())
 	if err != nil {
-		fmt.Println("NetworkID Error: ", err)
-		return nil, err
+		return nil, rpcErrorf("NetworkID Error: %v", err)
 	}
 
 	wallet := cli.wallet
@@ -215,11 +248,11 @@ func (cli *CLI) getBatchTransactOpts(address string) (*bind.TransactOpts, error)
 			break
 		}
 		if trials >= 1 {
-			return nil, fmt.Errorf("failed to unlock account %s (%v)", account.Address.String(), err)
+			return nil, configErrorf("failed to unlock account %s (%v)", account.Address.String(), err)
 
 		}
 		prompt := fmt.Sprintf("Unlocking account %s", account.Address.String())
-		passphrase, _ = getPassPhrase(prompt, false)
+		passphrase, _ = cli.getPassPhrase(prompt, false)
 	}
 
 	opts := NewBatchKeyedTransactorByAccount(wallet, account, networkID)
@@ -227,32 +260,76 @@ func (cli *CLI) getBatchTransactOpts(address string) (*bind.TransactOpts, error)
 	return opts, nil
 }
 
-// Execute parses the command line and processes it.
+// Execute parses the command line and processes it, and exits with the
+// exit code of the error category if the command failed.
 func (cli *CLI) Execute() {
-	cli.rootCmd.Execute()
+	cli.mu.Lock()
+	defer cli.mu.Unlock()
+
+	if err := cli.execute(); err != nil {
+		os.Exit(ExitCode(err))
+	}
+}
+
+func (cli *CLI) execute() error {
+	cli.jsonPrinted = false
+	cli.rootCmd.SetOut(cli.stdout)
+	cli.rootCmd.SetErr(cli.stderr)
+	cli.rootCmd.SetIn(cli.stdin)
+	err := cli.rootCmd.Execute()
+	if err != nil {
+		var e *Error
+		if !errors.As(err, &e) {
+			// the errors of args and flags parsing from cobra
+			err = &Error{Category: CategoryValidation, Err: err}
+		}
+		cli.showError(err)
+	}
+	return err
+}
+
+// showError prints the error of the command to stderr, or as the json
+// document in json output if the command has not printed one
+func (cli *CLI) showError(err error) {
+	if cli.isJSON() && !cli.jsonPrinted {
+		cli.printJSON(errorJSON{Error: errorDetailJSON{
+			Category: ErrorCategoryOf(err).String(),
+			Message:  err.Error(),
+		}})
+		return
+	}
+	fmt.Fprintln(cli.stderr, "Error:", err)
 }
 
 // setup turns up the CLI environment, and gets called by Cobra before
 // a command is executed.
-func (cli *CLI) setup(cmd *cobra.Command, args []string) {
+func (cli *CLI) setup(cmd *cobra.Command, args []string) error {
 	err := setupConfig(cli)
 	if err != nil {
-		fmt.Println(err)
-		fmt.Fprint(os.Stderr, cmd.UsageString())
-		os.Exit(1)
+		fmt.Fprint(cli.stderr, cmd.UsageString())
+		return &Error{Category: CategoryConfig, Err: err}
 	}
 	if cmd.Flags().Changed("symbol") {
 		if symbol, _ := cmd.Flags().GetString("symbol"); symbol != "" {
 			cli.localSymbol = symbol
 		}
 	}
+
+	return nil
 }
 
-func (cli *CLI) help(cmd *cobra.Command, args []string) {
-	fmt.Fprint(os.Stderr, cmd.UsageString())
+func (cli *CLI) help(cmd *cobra.Command, args []string) error {
+	fmt.Fprint(cli.stderr, cmd.UsageString())
+
+	return validationErrorf("command not set")
+}
 
-	os.Exit(-1)
+// unknownCommand is the RunE of the group commands, which run only for the
+// unknown subcommands
+func (cli *CLI) unknownCommand(cmd *cobra.Command, args []string) error {
+	fmt.Fprint(cli.stderr, cmd.UsageString())
 
+	return validationErrorf("unknown command %q for %q", args[0], cmd.CommandPath())
 }
 
 // TestCommand test command
@@ -263,34 +340,48 @@ func (cli *CLI) TestCommand(command string) string {
 	return result
 }
 
-// Run executes CLI with the given arguments. Used for testing. Not thread safe.
+// Run executes CLI with the given arguments and returns the output of
+// the command. Used for testing.
 func (cli *CLI) Run(args ...string) string {
-	oldStdout := os.Stdout
+	cli.mu.Lock()
+	defer cli.mu.Unlock()
 
-	r, w, _ := os.Pipe()
-
-	os.Stdout = w
+	var stdOut bytes.Buffer
+	stdout := cli.stdout
+	cli.stdout = &stdOut
+	defer func() {
+		cli.stdout = stdout
+	}()
 
 	cli.rootCmd.SetArgs(args)
-	cli.rootCmd.Execute()
+	cli.execute()
 	cli.buildRootCmd()
 
-	w.Close()
-
-	os.Stdout = oldStdout
-
-	var stdOut bytes.Buffer
-	io.Copy(&stdOut, r)
 	return stdOut.String()
 }
 
-// Embeddable returns a CLI that you can embed into your own Go programs. This
-// is not thread-safe.
+// Embeddable returns a CLI that you can embed into your own Go programs.
+// Use SetOutput and SetInput to redirect the input and output of the CLI.
 func (cli *CLI) Embeddable() *CLI {
 
 	return cli
 }
 
+// SetOutput sets the writers of the command output and the error
+// messages, os.Stdout and os.Stderr by default
+func (cli *CLI) SetOutput(stdout, stderr io.Writer) *CLI {
+	cli.stdout = stdout
+	cli.stderr = stderr
+	return cli
+}
+
+// SetInput sets the reader of the interactive prompts, os.Stdin by default
+func (cli *CLI) SetInput(stdin io.Reader) *CLI {
+	cli.stdin = stdin
+	cli.reader = bufio.NewReader(stdin)
+	return cli
+}
+
 // SetPassword SetPassword
 func (cli *CLI) SetPassword(_passPhrase string) *CLI {
 	cli.walletPassword = _passPhrase
@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestRunConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cli := NewCLI()
+			if out := cli.Run("version"); strings.TrimSpace(out) != cli.version {
+				t.Errorf("version: want %s, got %q", cli.version, out)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestSetInput(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	cli := NewCLI().SetOutput(&stdout, &stderr).SetInput(strings.NewReader("secret\nsecret\n"))
+
+	password, err := cli.getPassPhrase("Unlocking account", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if password != "secret" {
+		t.Errorf("want password secret, got %s", password)
+	}
+	if !strings.Contains(stdout.String(), "Unlocking account") {
+		t.Errorf("prompt not written to the output: %q", stdout.String())
+	}
+}
+
+func TestNewKeyedTransactorByAccount(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tokencommander-wallet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	wallet := keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP)
+	account, err := wallet.NewAccount("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	to := common.HexToAddress("0xDC8F76075Db000Fa70fdA3AA2c95d63F22A10a67")
+	tx := types.NewTransaction(0, to, big.NewInt(0), 21000, big.NewInt(1), nil)
+
+	opts := NewKeyedTransactorByAccount(&out, NewChain, wallet, account, "secret", big.NewInt(1007))
+	if _, err := opts.Signer(account.Address, tx); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), to.String()) {
+		t.Errorf("the tx not written to the writer: %q", out.String())
+	}
+
+	opts = NewKeyedTransactorByAccount(&out, NewChain, wallet, account, "wrong", big.NewInt(1007))
+	if _, err := opts.Signer(account.Address, tx); err == nil {
+		t.Error("want unlock error of the wrong passphrase")
+	}
+}
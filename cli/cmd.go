@@ -15,24 +15,27 @@ func (cli *CLI) buildRootCmd() {
 	}
 
 	short := fmt.Sprintf("%s is a commandline client on %s for users to interact with the %s contract",
-		cli.Name, cli.blockchain.String(), strings.Join(ModeERCList, "/"))
+		cli.Name, cli.blockchain.String(), strings.Join(cli.blockchain.ModeERCList(), "/"))
 	rootCmd := &cobra.Command{
-		Use:              cli.Name, // "TokenCommander",
-		Short:            short,
-		Run:              cli.help,
-		PersistentPreRun: cli.setup,
+		Use:               cli.Name, // "TokenCommander",
+		Short:             short,
+		RunE:              cli.help,
+		PersistentPreRunE: cli.setup,
+		SilenceErrors:     true,
+		SilenceUsage:      true,
 	}
 	cli.rootCmd = rootCmd
 
 	// Global flags
 	rootCmd.PersistentFlags().StringVarP(&cli.config, "config", "c", defaultConfigFile, "The `path` to config file")
 	rootCmd.PersistentFlags().StringP("walletPath", "w", defaultWalletPath, "Wallet storage `directory`")
-	rootCmd.PersistentFlags().StringP("rpcURL", "i", defaultRPCURL, fmt.Sprintf("%s json rpc or ipc `url`", cli.blockchain.String()))
+	rootCmd.PersistentFlags().StringP("rpcURL", "i", cli.blockchain.DefaultRPCURL(), fmt.Sprintf("%s json rpc or ipc `url`", cli.blockchain.String()))
 	rootCmd.PersistentFlags().StringP("contractAddress", "a", defaultContractAddress, "Contract `address`")
 	rootCmd.PersistentFlags().StringP("from", "f", "", "the from `address` who pay gas")
 
-	rootCmd.PersistentFlags().String("mode", ModeERC20, fmt.Sprintf(`use %s token`, strings.Join(ModeERCList, "|")))
+	rootCmd.PersistentFlags().String("mode", cli.blockchain.ModeERC20(), fmt.Sprintf(`use %s token`, strings.Join(cli.blockchain.ModeERCList(), "|")))
 	rootCmd.PersistentFlags().StringP("symbol", "s", "", "the symbol of the contract, this'll overwrite the `--contractAddress` when load token")
+	rootCmd.PersistentFlags().StringP("output", "o", outputText, fmt.Sprintf("output `format`, %s", strings.Join(outputList, "|")))
 
 	// Basic commands
 	rootCmd.AddCommand(cli.buildInitCmd())    // init
@@ -51,12 +54,40 @@ func (cli *CLI) buildRootCmd() {
 	rootCmd.AddCommand(cli.buildPayCmd())
 	rootCmd.AddCommand(cli.buildBatchPayCmd()) // batch pay
 
+	// burn
+	rootCmd.AddCommand(cli.buildBurnCmd())
+
+	// allowance
+	rootCmd.AddCommand(cli.buildAllowanceCmd())
+
 	// balance
 	rootCmd.AddCommand(cli.buildBalanceCmd())
 
+	// history
+	rootCmd.AddCommand(cli.buildHistoryCmd())
+
 	// ERC721
 	rootCmd.AddCommand(cli.buildMintCmd()) // mint
 
+	// nft
+	rootCmd.AddCommand(cli.buildNFTCmd())
+
+	// pause
+	rootCmd.AddCommand(cli.buildPauseCmd(true))
+	rootCmd.AddCommand(cli.buildPauseCmd(false)) // unpause
+
+	// enable transfer
+	rootCmd.AddCommand(cli.buildEnableTransferCmd())
+
+	// recover
+	rootCmd.AddCommand(cli.buildRecoverCmd())
+
+	// owner
+	rootCmd.AddCommand(cli.buildOwnerCmd())
+
+	// role
+	rootCmd.AddCommand(cli.buildRoleCmd())
+
 	// add
 	rootCmd.AddCommand(cli.buildAddCmd())
 
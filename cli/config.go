@@ -3,39 +3,31 @@ package cli
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/spf13/viper"
 )
 
 const defaultConfigFile = "./config.toml"
 const defaultWalletPath = "./wallet/"
 const defaultContractAddress = ""
 
-var defaultRPCURL string
-
 const defaultNEWRPCURL = "https://rpc1.newchain.newtonproject.org"
 const defaultETHRPCUrl = "https://ethrpc.service.newtonproject.org"
 
-func InitRPCUrl(bc BlockChain) {
-	// default RPC Url
-	defaultRPCURL = defaultETHRPCUrl
-	if bc == NewChain {
-		defaultRPCURL = defaultNEWRPCURL
-	}
-}
-
 func defaultConfig(cli *CLI) {
-	viper.BindPFlag("walletPath", cli.rootCmd.PersistentFlags().Lookup("walletPath"))
-	viper.BindPFlag("rpcURL", cli.rootCmd.PersistentFlags().Lookup("rpcURL"))
-	viper.BindPFlag("contractAddress", cli.rootCmd.PersistentFlags().Lookup("contractAddress"))
-	viper.BindPFlag("from", cli.rootCmd.PersistentFlags().Lookup("from"))
-	viper.BindPFlag("mode", cli.rootCmd.PersistentFlags().Lookup("mode"))
+	cli.v.BindPFlag("walletPath", cli.rootCmd.PersistentFlags().Lookup("walletPath"))
+	cli.v.BindPFlag("rpcURL", cli.rootCmd.PersistentFlags().Lookup("rpcURL"))
+	cli.v.BindPFlag("contractAddress", cli.rootCmd.PersistentFlags().Lookup("contractAddress"))
+	cli.v.BindPFlag("from", cli.rootCmd.PersistentFlags().Lookup("from"))
+	cli.v.BindPFlag("mode", cli.rootCmd.PersistentFlags().Lookup("mode"))
+	cli.v.BindPFlag("output", cli.rootCmd.PersistentFlags().Lookup("output"))
 
-	viper.SetDefault("walletPath", defaultWalletPath)
-	viper.SetDefault("rpcURL", defaultRPCURL)
-	viper.SetDefault("contractAddress", defaultContractAddress)
-	viper.SetDefault("mode", ModeERC20)
+	cli.v.SetDefault("walletPath", defaultWalletPath)
+	cli.v.SetDefault("rpcURL", cli.blockchain.DefaultRPCURL())
+	cli.v.SetDefault("contractAddress", defaultContractAddress)
+	cli.v.SetDefault("mode", cli.blockchain.ModeERC20())
+	cli.v.SetDefault("output", outputText)
 }
 
 func setupConfig(cli *CLI) error {
@@ -45,13 +37,13 @@ func setupConfig(cli *CLI) error {
 
 	defaultConfig(cli)
 
-	viper.SetConfigName(defaultConfigFile)
-	viper.AddConfigPath(".")
+	cli.v.SetConfigName(defaultConfigFile)
+	cli.v.AddConfigPath(".")
 	cfgFile := cli.config
 	if cfgFile != "" {
 		if _, err = os.Stat(cfgFile); err == nil {
-			viper.SetConfigFile(cfgFile)
-			err = viper.ReadInConfig()
+			cli.v.SetConfigFile(cfgFile)
+			err = cli.v.ReadInConfig()
 		} else {
 			// The default configuration is enabled.
 			// fmt.Println(err)
@@ -62,27 +54,33 @@ func setupConfig(cli *CLI) error {
 		err = nil
 	}
 
-	if rpcURL := viper.GetString("rpcURL"); rpcURL != "" {
+	if rpcURL := cli.v.GetString("rpcURL"); rpcURL != "" {
 		cli.rpcURL = rpcURL
 	}
-	if walletPath := viper.GetString("walletPath"); walletPath != "" {
+	if walletPath := cli.v.GetString("walletPath"); walletPath != "" {
 		cli.walletPath = walletPath
 	}
-	if walletPassword := viper.GetString("Password"); walletPassword != "" {
+	if walletPassword := cli.v.GetString("Password"); walletPassword != "" {
 		cli.walletPassword = walletPassword
 	}
-	if contractAddress := viper.GetString("contractAddress"); contractAddress != "" && common.IsHexAddress(contractAddress) {
+	if contractAddress := cli.v.GetString("contractAddress"); contractAddress != "" && common.IsHexAddress(contractAddress) {
 		cli.contractAddress = contractAddress
 	}
-	if address := viper.GetString("from"); address != "" && common.IsHexAddress(address) {
+	if address := cli.v.GetString("from"); address != "" && common.IsHexAddress(address) {
 		cli.address = address
 	}
-	if mode := viper.GetString("mode"); mode != "" {
-		if mode != ModeERC20 && mode != ModeERC721 {
-			return fmt.Errorf("not support mode %s, only support %s|%s", mode, ModeERC20, ModeERC721)
+	if mode := cli.v.GetString("mode"); mode != "" {
+		if !stringInSlice(mode, cli.blockchain.ModeERCList()) {
+			return fmt.Errorf("not support mode %s, only support %s", mode, strings.Join(cli.blockchain.ModeERCList(), "|"))
 		}
 		cli.mode = mode
 	}
+	if output := cli.v.GetString("output"); output != "" {
+		if !stringInSlice(output, outputList) {
+			return fmt.Errorf("not support output %s, only support %s|%s", output, outputText, outputJSON)
+		}
+		cli.output = output
+	}
 
 	return err
 }
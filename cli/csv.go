@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"encoding/csv"
+	"io"
+	"os"
+	"strings"
+)
+
+// csvRow is a row of the csv file with its record number, from 1 and
+// counting the header
+type csvRow struct {
+	Row    int
+	Fields []string
+}
+
+// readCSVRows reads the rows of the csv file with the columns of header.
+// The column in brackets such as [uri] is optional and empty if omitted,
+// the following columns should be optional too. The header row is
+// optional, the blank lines and the lines starting with # are skipped, and
+// the fields are trimmed.
+func readCSVRows(path string, header ...string) ([]csvRow, error) {
+	required := len(header)
+	names := make([]string, len(header))
+	for i, name := range header {
+		if strings.HasPrefix(name, "[") && strings.HasSuffix(name, "]") {
+			name = name[1 : len(name)-1]
+			if i < required {
+				required = i
+			}
+		}
+		names[i] = name
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, validationErrorf("%v", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var rows []csvRow
+	for n := 1; ; n++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, validationErrorf("parse %s error: %v", path, err)
+		}
+		if len(record) < required || len(record) > len(names) {
+			return nil, validationErrorf("parse %s error: record on row %d: wrong number of fields", path, n)
+		}
+		for i := range record {
+			record[i] = strings.TrimSpace(record[i])
+		}
+		if n == 1 && isCSVHeader(record, names) {
+			continue
+		}
+		for len(record) < len(names) {
+			record = append(record, "")
+		}
+		rows = append(rows, csvRow{Row: n, Fields: record})
+	}
+
+	return rows, nil
+}
+
+func isCSVHeader(record, names []string) bool {
+	for i := range record {
+		if !strings.EqualFold(record[i], names[i]) {
+			return false
+		}
+	}
+	return true
+}
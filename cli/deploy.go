@@ -5,92 +5,150 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/newtonproject/tokencommander/token"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 func (cli *CLI) buildDeployCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:                   "deploy <--name tokenname> <--symbol tokensymbol> <--total totalSupplyAmount> [--decimals decimal]",
+		Use:                   "deploy <--name tokenname> <--symbol tokensymbol> <--total totalSupplyAmount> [--decimals decimal] [--cap capAmount] [--initial-supply amount] [--mintable] [--transfer-enabled]",
 		Short:                 fmt.Sprintf("Deploy %s contract", cli.blockchain.String()),
 		DisableFlagsInUseLine: true,
-		Run: func(cmd *cobra.Command, args []string) {
+		RunE: func(cmd *cobra.Command, args []string) error {
 
 			save, _ := cmd.Flags().GetBool("save")
 
-			fromAddress := viper.GetString("from")
+			fromAddress := cli.v.GetString("from")
 			if fromAddress == "" || !common.IsHexAddress(fromAddress) {
-				fmt.Println("Error: not set from address of owner")
-				fmt.Println(cmd.UsageString())
-				return
+				fmt.Fprint(cli.stderr, cmd.UsageString())
+				return validationErrorf("not set from address of owner")
 			}
 
 			name, _ := cmd.Flags().GetString("name")
 			if name == "" {
-				fmt.Println("Error: not set name")
-				fmt.Println(cmd.UsageString())
-				return
+				fmt.Fprint(cli.stderr, cmd.UsageString())
+				return validationErrorf("not set name")
 			}
 
 			symbol, _ := cmd.Flags().GetString("symbol")
 			if symbol == "" {
-				fmt.Println("Error: not set symbol")
-				fmt.Println(cmd.UsageString())
-				return
+				fmt.Fprint(cli.stderr, cmd.UsageString())
+				return validationErrorf("not set symbol")
 			}
 
 			var decimals uint8
-			var totalSupply *big.Int
+			var capSupply, initialSupply *big.Int
 			var baseTokenURI string
-			if cli.mode != ModeERC721 {
+			mintable, _ := cmd.Flags().GetBool("mintable")
+			if cli.mode != cli.blockchain.ModeERC721() {
 				decimals, _ = cmd.Flags().GetUint8("decimals")
 				if decimals < 0 || decimals > 18 {
-					fmt.Println("Error: not set decimals or decimals invalid")
-					fmt.Println(cmd.UsageString())
-					return
+					fmt.Fprint(cli.stderr, cmd.UsageString())
+					return validationErrorf("not set decimals or decimals invalid")
 				}
 
-				totalSupplyStr, _ := cmd.Flags().GetString("total")
-				if totalSupplyStr == "" {
-					fmt.Println("totalSupply not set")
-					return
+				parseSupply := func(flag string) (*big.Int, error) {
+					amountStr, _ := cmd.Flags().GetString(flag)
+					if !IsDecimalString(amountStr) {
+						return nil, validationErrorf("%s(%v) illegal", flag, amountStr)
+					}
+					amount, ok := getWeiAmountWeiByStringWithDecimals(amountStr, 10, decimals)
+					if !ok {
+						fmt.Fprint(cli.stderr, cmd.UsageString())
+						return nil, validationErrorf("%s(%v) invalid", flag, amountStr)
+					}
+					return amount, nil
 				}
-				if !IsDecimalString(totalSupplyStr) {
-					fmt.Printf("totalSupply(%v) illegal\n", totalSupplyStr)
-					return
+
+				if cmd.Flags().Changed("initial-supply") && cmd.Flags().Changed("total") {
+					return validationErrorf("--total and --initial-supply are mutually exclusive")
+				}
+
+				var err error
+				switch {
+				case cmd.Flags().Changed("initial-supply"):
+					initialSupply, err = parseSupply("initial-supply")
+				case cmd.Flags().Changed("total"):
+					initialSupply, err = parseSupply("total")
+				case mintable:
+					initialSupply = big.NewInt(0)
+				default:
+					return validationErrorf("totalSupply not set")
+				}
+				if err != nil {
+					return err
+				}
+
+				if cmd.Flags().Changed("cap") {
+					capSupply, err = parseSupply("cap")
+					if err != nil {
+						return err
+					}
+				} else if mintable {
+					fmt.Fprint(cli.stderr, cmd.UsageString())
+					return validationErrorf("the cap of mintable token not set")
+				} else {
+					capSupply = initialSupply
 				}
-				var ok bool
-				totalSupply, ok = getWeiAmountWeiByStringWithDecimals(totalSupplyStr, 10, decimals)
-				if !ok {
-					fmt.Println("Error: totalSupply invalid")
-					fmt.Println(cmd.UsageString())
-					return
+				if capSupply.Sign() <= 0 {
+					return validationErrorf("the cap should be greater than 0")
+				}
+				if initialSupply.Cmp(capSupply) > 0 {
+					return validationErrorf("the initial supply(%s) exceeds the cap(%s)",
+						getAmountTextByWeiWithDecimals(initialSupply, decimals), getAmountTextByWeiWithDecimals(capSupply, decimals))
+				}
+				// the constructor requires the cap equal to the initial supply if minting finished
+				if !mintable && initialSupply.Cmp(capSupply) != 0 {
+					return validationErrorf("the cap(%s) should be equal to the initial supply(%s) if not mintable, set --mintable to mint the rest later",
+						getAmountTextByWeiWithDecimals(capSupply, decimals), getAmountTextByWeiWithDecimals(initialSupply, decimals))
 				}
 			} else {
 				var err error
 				baseTokenURI, err = cmd.Flags().GetString("base")
 				if err != nil {
-					fmt.Println(err)
-					return
+					return validationErrorf("%v", err)
 				}
 			}
 
 			if cli.contractAddress == "" {
 				save = true
 			}
-			cli.Deploy(fromAddress, name, symbol, baseTokenURI, decimals, totalSupply)
+			transferEnabled, _ := cmd.Flags().GetBool("transfer-enabled")
+			params := token.DeployParams{
+				Kind:            cli.tokenKind(),
+				Name:            name,
+				Symbol:          symbol,
+				Decimals:        decimals,
+				Cap:             capSupply,
+				InitialSupply:   initialSupply,
+				TransferEnabled: transferEnabled,
+				MintingFinished: !mintable,
+				BaseTokenURI:    baseTokenURI,
+			}
+			if err := cli.deploy(fromAddress, params); err != nil {
+				return err
+			}
 
 			if save {
-				viper.WriteConfigAs(cli.config)
+				if err := cli.v.WriteConfigAs(cli.config); err != nil {
+					return configErrorf("WriteConfig: %v", err)
+				}
 			}
+
+			return nil
 		},
 	}
 
 	cmd.Flags().StringP("name", "n", "", "the name of the token")
 	cmd.Flags().Uint8P("decimals", "d", 18, "the decimals of the token, 0~18")
-	cmd.Flags().StringP("total", "t", "", "the total supply of the token")
+	cmd.Flags().StringP("total", "t", "", "the total supply of the token, which is the initial supply and the cap if they are not set")
+	cmd.Flags().String("initial-supply", "", fmt.Sprintf("the initial supply minted to the from address, only for %s", cli.blockchain.ModeERC20()))
+	cmd.Flags().String("cap", "", fmt.Sprintf("the cap of the total supply, default is the initial supply, only for %s", cli.blockchain.ModeERC20()))
+	cmd.Flags().Bool("mintable", false, fmt.Sprintf("keep minting unfinished for the MINTER role up to the cap, required if the cap is greater than the initial supply, only for %s", cli.blockchain.ModeERC20()))
+
+	cmd.Flags().Bool("transfer-enabled", true, fmt.Sprintf("enable the transfer of the token when deployed, set false to launch locked, only for %s", cli.blockchain.ModeERC20()))
 
-	cmd.Flags().StringP("base", "b", "", fmt.Sprintf("the base token URI for %s", ModeERC721))
+	cmd.Flags().StringP("base", "b", "", fmt.Sprintf("the base token URI for %s", cli.blockchain.ModeERC721()))
 
 	cmd.Flags().Bool("save", false, "save contract address to config file")
 
@@ -1,9 +1,34 @@
 package cli
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestDeploy(t *testing.T) {
 	cli := NewCLI()
 
 	cli.TestCommand("deploy --name MyToken --symbol MT --total 100000000 --decimals 1")
 }
+
+func TestDeployCap(t *testing.T) {
+	cli := NewCLI()
+
+	cli.TestCommand("deploy --name MyToken --symbol MT --cap 100000000 --initial-supply 1000 --mintable --decimals 1")
+	cli.TestCommand("deploy --name MyToken --symbol MT --cap 1000 --initial-supply 100000000 --decimals 1")
+
+	// the cap should be equal to the initial supply if not mintable
+	cli.rootCmd.SetArgs([]string{"deploy", "--from", "0xDC8F76075Db000Fa70fdA3AA2c95d63F22A10a67", "--name", "MyToken", "--symbol", "MT",
+		"--cap", "100000000", "--initial-supply", "1000", "--decimals", "1"})
+	err := cli.execute()
+	if got := ExitCode(err); got != int(CategoryValidation) || !strings.Contains(err.Error(), "not mintable") {
+		t.Errorf("deploy with cap greater than initial supply but not mintable: want validation error, got %v", err)
+	}
+
+	cli.rootCmd.SetArgs([]string{"deploy", "--from", "0xDC8F76075Db000Fa70fdA3AA2c95d63F22A10a67", "--name", "MyToken", "--symbol", "MT",
+		"--total", "1000", "--initial-supply", "1000", "--decimals", "1"})
+	err = cli.execute()
+	if got := ExitCode(err); got != int(CategoryValidation) || !strings.Contains(err.Error(), "mutually exclusive") {
+		t.Errorf("deploy with both total and initial supply: want validation error, got %v", err)
+	}
+}
@@ -3,56 +3,119 @@ package cli
 
 import (
 	"context"
-	"fmt"
 	"math/big"
 	"time"
 
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
-	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/newtonproject/tokencommander/contracts/ERC20"
-	"github.com/newtonproject/tokencommander/contracts/ERC721"
-	"github.com/spf13/viper"
+	"github.com/newtonproject/tokencommander/token"
 )
 
+type deployJSON struct {
+	Mode            string      `json:"mode"`
+	Name            string      `json:"name"`
+	Symbol          string      `json:"symbol"`
+	ContractAddress string      `json:"contractAddress"`
+	TotalSupply     *amountJSON `json:"totalSupply,omitempty"`
+	Cap             *amountJSON `json:"cap,omitempty"`
+	TransferEnabled *bool       `json:"transferEnabled,omitempty"`
+	Mintable        *bool       `json:"mintable,omitempty"`
+	BaseTokenURI    string      `json:"baseTokenURI,omitempty"`
+	Tx              *txJSON     `json:"tx"`
+}
+
 // Deploy deploy contract
-func (cli *CLI) Deploy(address, name, symbol, baseTokenURI string, decimals uint8, totalSupply *big.Int) {
+func (cli *CLI) Deploy(address, name, symbol, baseTokenURI string, decimals uint8, totalSupply *big.Int) error {
+	return cli.deploy(address, token.DeployParams{
+		Kind:            cli.tokenKind(),
+		Name:            name,
+		Symbol:          symbol,
+		Decimals:        decimals,
+		Cap:             totalSupply,
+		InitialSupply:   totalSupply,
+		TransferEnabled: true,
+		MintingFinished: true,
+		BaseTokenURI:    baseTokenURI,
+	})
+}
+
+func (cli *CLI) deploy(address string, params token.DeployParams) error {
 	var err error
 
+	cli.showDeployParams(params)
+
 	opts, err := cli.getTransactOpts(address)
 	if err != nil {
-		fmt.Println("GetTransactOpts: ", err)
-		return
+		return err
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
 	defer cancel()
 	opts.Context = ctx
 
-	cli.BuildClient()
-	client := cli.client
-	var contractAddress common.Address
-	tx := new(types.Transaction)
-	if cli.mode == ModeERC721 {
-		contractAddress, tx, _, err = ERC721.DeployNRC7Full(opts, client, name, symbol, baseTokenURI)
-	} else {
-		contractAddress, tx, _, err = ERC20.DeployBaseToken(opts, client, name, symbol, decimals,
-			totalSupply, totalSupply, true, true)
+	if err := cli.BuildClient(); err != nil {
+		return err
 	}
+	client := cli.client
+	contractAddress, tx, err := token.Deploy(opts, client, params)
 	if err != nil {
-		fmt.Println("DeployContract error: ", err)
-		return
+		return tokenErrorf(CategoryRPC, "DeployContract error: %w", err)
 	}
 
-	fmt.Printf("Contract %s deploy at address %s\n", cli.mode, contractAddress.String())
-	fmt.Printf("Transaction waiting to be mined: 0x%x\n", tx.Hash())
+	cli.printf("Contract %s deploy at address %s\n", cli.mode, contractAddress.String())
+	cli.printf("Transaction waiting to be mined: 0x%x\n", tx.Hash())
 	cli.contractAddress = contractAddress.String()
-	viper.Set("contractaddress", cli.contractAddress)
-	_, err = bind.WaitDeployed(opts.Context, client, tx)
+	cli.v.Set("contractaddress", cli.contractAddress)
+	receipt, err := token.WaitDeployed(opts.Context, client, tx)
 	if err != nil {
-		fmt.Println("WaitDeployed error: ", err)
-		return
+		if receipt != nil && !receipt.Succeeded() {
+			return revertedErrorf("WaitDeployed error: %v", err)
+		}
+		return rpcErrorf("WaitDeployed error: %v", err)
 	}
 
-	fmt.Printf("Contract %s deploy success\n", cli.mode)
+	cli.printf("Contract %s deploy success\n", cli.mode)
+	if params.Kind == token.Fungible && !params.TransferEnabled {
+		cli.println("The transfer of the token is not enabled, use command enable-transfer to enable it")
+	}
+
+	if cli.isJSON() {
+		result := deployJSON{
+			Mode:            cli.mode,
+			Name:            params.Name,
+			Symbol:          params.Symbol,
+			ContractAddress: contractAddress.String(),
+			BaseTokenURI:    params.BaseTokenURI,
+			Tx:              newTxJSON(tx, receipt),
+		}
+		if params.Kind == token.Fungible {
+			result.TotalSupply = newAmountJSON(params.InitialSupply, params.Decimals, params.Symbol)
+			result.Cap = newAmountJSON(params.Cap, params.Decimals, params.Symbol)
+			transferEnabled := params.TransferEnabled
+			result.TransferEnabled = &transferEnabled
+			mintable := !params.MintingFinished
+			result.Mintable = &mintable
+		}
+		cli.printJSON(result)
+	}
+
+	return nil
+}
+
+// showDeployParams shows the constructor arguments before signing
+func (cli *CLI) showDeployParams(params token.DeployParams) {
+	cli.printf("Deploy %s contract with:\n", cli.mode)
+	cli.printf("\tName: %s\n", params.Name)
+	cli.printf("\tSymbol: %s\n", params.Symbol)
+	if params.Kind == token.NonFungible {
+		cli.printf("\tBaseTokenURI: %s\n", params.BaseTokenURI)
+		return
+	}
+	cli.printf("\tDecimals: %d\n", params.Decimals)
+	if params.InitialSupply != nil {
+		cli.printf("\tInitialSupply: %s %s\n", getAmountTextByWeiWithDecimals(params.InitialSupply, params.Decimals), params.Symbol)
+	}
+	if params.Cap != nil {
+		cli.printf("\tCap: %s %s\n", getAmountTextByWeiWithDecimals(params.Cap, params.Decimals), params.Symbol)
+	}
+	cli.printf("\tTransferEnabled: %v\n", params.TransferEnabled)
+	cli.printf("\tMintable: %v\n", !params.MintingFinished)
 }
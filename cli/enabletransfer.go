@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/newtonproject/tokencommander/token"
+	"github.com/spf13/cobra"
+)
+
+type enableTransferJSON struct {
+	TransferEnabled bool    `json:"transferEnabled"`
+	Tx              *txJSON `json:"tx,omitempty"`
+}
+
+func (cli *CLI) buildEnableTransferCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "enable-transfer",
+		Short:                 fmt.Sprintf("Enable the transfer of the token permanently, only for %s", cli.blockchain.ModeERC20()),
+		Args:                  cobra.NoArgs,
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cli.mode != cli.blockchain.ModeERC20() {
+				return validationErrorf("%v", cli.blockchain.errOnlyERC20())
+			}
+			if cli.address == "" || !common.IsHexAddress(cli.address) {
+				return configErrorf("not set from address of owner or from address illegal")
+			}
+
+			tok, err := cli.GetToken()
+			if err != nil {
+				return err
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+			defer cancel()
+
+			opts, err := cli.getTransactOpts(cli.address)
+			if err != nil {
+				return err
+			}
+			opts.Context = ctx
+
+			tx, err := tok.EnableTransfer(opts)
+			if errors.Is(err, token.ErrTransferEnabled) {
+				cli.println("The transfer of the token is enabled already")
+				if cli.isJSON() {
+					cli.printJSON(enableTransferJSON{TransferEnabled: true})
+				}
+				return nil
+			}
+			if err != nil {
+				return tokenErrorf(CategoryRPC, "enable transfer error(%w)", err)
+			}
+			cli.printf("Succeed submit enable transfer, TxID %s.\n", tx.Hash().String())
+
+			receipt, err := cli.waitMined(ctx, tx)
+			if err != nil {
+				return err
+			}
+
+			enabled, err := tok.TransferEnabled(ctx)
+			if err != nil {
+				return rpcErrorf("TransferEnabled: Get transfer enabled Error(%v)", err)
+			}
+			if cli.isJSON() {
+				cli.printJSON(enableTransferJSON{
+					TransferEnabled: enabled,
+					Tx:              newTxJSON(tx, receipt),
+				})
+			}
+
+			if !receipt.Succeeded() {
+				return revertedErrorf("the tx %s is confirmed but status is failed", tx.Hash().String())
+			}
+			cli.println("The transfer of the token is enabled")
+
+			return nil
+		},
+	}
+
+	return cmd
+}
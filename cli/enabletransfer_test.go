@@ -0,0 +1,10 @@
+package cli
+
+import "testing"
+
+func TestEnableTransfer(t *testing.T) {
+	cli := NewCLI()
+
+	cli.TestCommand("deploy --name MyToken --symbol MT --total 100000000 --decimals 1 --transfer-enabled=false")
+	cli.TestCommand("enable-transfer")
+}
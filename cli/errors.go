@@ -0,0 +1,142 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/newtonproject/tokencommander/token"
+)
+
+// ErrorCategory is the category of a command error, the exit code of the
+// CLI is the value of the category
+type ErrorCategory int
+
+const (
+	// CategoryGeneral is for the errors without category
+	CategoryGeneral ErrorCategory = iota + 1
+	// CategoryConfig is for config file, wallet and contract settings errors
+	CategoryConfig
+	// CategoryRPC is for json rpc errors
+	CategoryRPC
+	// CategoryValidation is for illegal arguments or flags
+	CategoryValidation
+	// CategoryInsufficientFunds is for not enough balance to pay
+	CategoryInsufficientFunds
+	// CategoryReverted is for the tx which will always fail or failed after mined
+	CategoryReverted
+)
+
+func (c ErrorCategory) String() string {
+	switch c {
+	case CategoryConfig:
+		return "config"
+	case CategoryRPC:
+		return "rpc"
+	case CategoryValidation:
+		return "validation"
+	case CategoryInsufficientFunds:
+		return "insufficient_funds"
+	case CategoryReverted:
+		return "reverted"
+	}
+
+	return "general"
+}
+
+// Error is a command error with category
+type Error struct {
+	Category ErrorCategory
+	Err      error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func newError(category ErrorCategory, format string, a ...interface{}) error {
+	return &Error{Category: category, Err: fmt.Errorf(format, a...)}
+}
+
+func configErrorf(format string, a ...interface{}) error {
+	return newError(CategoryConfig, format, a...)
+}
+
+func rpcErrorf(format string, a ...interface{}) error {
+	return newError(CategoryRPC, format, a...)
+}
+
+func validationErrorf(format string, a ...interface{}) error {
+	return newError(CategoryValidation, format, a...)
+}
+
+func fundsErrorf(format string, a ...interface{}) error {
+	return newError(CategoryInsufficientFunds, format, a...)
+}
+
+func revertedErrorf(format string, a ...interface{}) error {
+	return newError(CategoryReverted, format, a...)
+}
+
+// tokenErrorf wraps the error returned by the token package with the
+// category of its sentinel error, or with category fallback.
+// The format should contain one %w verb for the error.
+func tokenErrorf(fallback ErrorCategory, format string, a ...interface{}) error {
+	err := fmt.Errorf(format, a...)
+
+	category := fallback
+	switch {
+	case errors.Is(err, token.ErrInsufficientBalance),
+		errors.Is(err, token.ErrInsufficientAllowance):
+		category = CategoryInsufficientFunds
+	case errors.Is(err, token.ErrAlwaysFailing),
+		errors.Is(err, token.ErrExecutionReverted):
+		category = CategoryReverted
+	case errors.Is(err, token.ErrInvalidAmount),
+		errors.Is(err, token.ErrNotTokenOwner),
+		errors.Is(err, token.ErrNotApproved),
+		errors.Is(err, token.ErrTokenNotExists),
+		errors.Is(err, token.ErrDuplicateTokenID),
+		errors.Is(err, token.ErrSelfApproval),
+		errors.Is(err, token.ErrNotMinter),
+		errors.Is(err, token.ErrNotPauser),
+		errors.Is(err, token.ErrPaused),
+		errors.Is(err, token.ErrNotPaused),
+		errors.Is(err, token.ErrNotOwner),
+		errors.Is(err, token.ErrZeroAddress),
+		errors.Is(err, token.ErrUnknownRole),
+		errors.Is(err, token.ErrNotRoleAdmin),
+		errors.Is(err, token.ErrNoRole),
+		errors.Is(err, token.ErrMintingFinished),
+		errors.Is(err, token.ErrTransferDisabled),
+		errors.Is(err, token.ErrTransferEnabled),
+		errors.Is(err, token.ErrCapExceeded),
+		errors.Is(err, token.ErrNotERC1363Receiver),
+		errors.Is(err, token.ErrNotERC1363Spender),
+		errors.Is(err, token.ErrOnlyFungible),
+		errors.Is(err, token.ErrOnlyNonFungible):
+		category = CategoryValidation
+	}
+
+	return &Error{Category: category, Err: err}
+}
+
+// ErrorCategoryOf returns the category of the command error
+func ErrorCategoryOf(err error) ErrorCategory {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Category
+	}
+	return CategoryGeneral
+}
+
+// ExitCode returns the exit code of the command error, 0 for nil
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	return int(ErrorCategoryOf(err))
+}
@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/newtonproject/tokencommander/token"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{nil, 0},
+		{errors.New("unknown"), 1},
+		{configErrorf("config"), 2},
+		{rpcErrorf("rpc"), 3},
+		{validationErrorf("validation"), 4},
+		{fundsErrorf("funds"), 5},
+		{revertedErrorf("reverted"), 6},
+		{tokenErrorf(CategoryRPC, "pay: %w", token.ErrInsufficientBalance), 5},
+		{tokenErrorf(CategoryRPC, "pay: %w", token.ErrAlwaysFailing), 6},
+		{tokenErrorf(CategoryRPC, "pay: %w", token.ErrNotTokenOwner), 4},
+		{tokenErrorf(CategoryRPC, "pay: %w", token.ErrExecutionReverted), 6},
+		{tokenErrorf(CategoryRPC, "pay: %w", token.ErrNotERC1363Receiver), 4},
+		{tokenErrorf(CategoryRPC, "pay: %w", token.ErrPaused), 4},
+		{tokenErrorf(CategoryRPC, "pay: %w", errors.New("connection refused")), 3},
+	}
+	for _, tt := range tests {
+		if got := ExitCode(tt.err); got != tt.want {
+			t.Errorf("ExitCode(%v): want %d, got %d", tt.err, tt.want, got)
+		}
+	}
+}
+
+func TestCommandExitCode(t *testing.T) {
+	cli := NewCLI()
+
+	cli.rootCmd.SetArgs([]string{"balance", "0xinvalid"})
+	if got := ExitCode(cli.execute()); got != int(CategoryValidation) {
+		t.Errorf("balance with invalid address: want exit code %d, got %d", CategoryValidation, got)
+	}
+}
+
+func TestUnknownSubcommandExitCode(t *testing.T) {
+	for _, args := range [][]string{
+		{"account", "lsit"},
+		{"nft", "uri", "sett", "1", "x"},
+		{"role", "lst"},
+	} {
+		cli := NewCLI()
+		cli.SetOutput(ioutil.Discard, ioutil.Discard)
+		cli.rootCmd.SetArgs(args)
+		if got := ExitCode(cli.execute()); got != int(CategoryValidation) {
+			t.Errorf("%v: want exit code %d, got %d", args, CategoryValidation, got)
+		}
+	}
+}
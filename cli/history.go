@@ -0,0 +1,222 @@
+package cli
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/newtonproject/tokencommander/token"
+	"github.com/spf13/cobra"
+)
+
+type historyEventJSON struct {
+	BlockNumber uint64      `json:"blockNumber"`
+	Time        string      `json:"time,omitempty"`
+	TxHash      string      `json:"txHash"`
+	LogIndex    uint        `json:"logIndex"`
+	Direction   string      `json:"direction"`
+	From        string      `json:"from"`
+	To          string      `json:"to"`
+	Amount      *amountJSON `json:"amount,omitempty"`
+	TokenID     string      `json:"tokenID,omitempty"`
+}
+
+type historyJSON struct {
+	Address   string             `json:"address"`
+	FromBlock uint64             `json:"fromBlock"`
+	ToBlock   *uint64            `json:"toBlock,omitempty"`
+	Output    string             `json:"output,omitempty"`
+	Events    []historyEventJSON `json:"events"`
+}
+
+var historyDirections = map[string]token.Direction{
+	"all": token.DirectionAll,
+	"in":  token.DirectionIn,
+	"out": token.DirectionOut,
+}
+
+func (cli *CLI) buildHistoryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "history [address] [--from-block n] [--to-block n] [--direction all|in|out] [--out history.csv]",
+		Short:                 "Show the transfer history of the address, default is the from address",
+		Args:                  cobra.MaximumNArgs(1),
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			addressStr := cli.address
+			if len(args) > 0 {
+				addressStr = args[0]
+			}
+			if addressStr == "" {
+				fmt.Fprint(cli.stderr, cmd.UsageString())
+				return validationErrorf("not set address or from address")
+			}
+
+			directionStr, _ := cmd.Flags().GetString("direction")
+			direction, ok := historyDirections[directionStr]
+			if !ok {
+				return validationErrorf("unknown direction %s, use all, in or out", directionStr)
+			}
+
+			q := token.HistoryQuery{Direction: direction}
+			q.FromBlock, _ = cmd.Flags().GetUint64("from-block")
+			if cmd.Flags().Changed("to-block") {
+				toBlock, _ := cmd.Flags().GetUint64("to-block")
+				q.ToBlock = &toBlock
+			}
+			q.Chunk, _ = cmd.Flags().GetUint64("chunk")
+			if q.Chunk == 0 {
+				return validationErrorf("the chunk should be greater than 0")
+			}
+
+			if err := cli.BuildClient(); err != nil {
+				return err
+			}
+			chainID, err := cli.client.NetworkID(context.Background())
+			if err != nil {
+				return rpcErrorf("NetworkID Error: %v", err)
+			}
+			q.Account, err = cli.parseBatchAddress(chainID, addressStr)
+			if err != nil {
+				return err
+			}
+
+			output, _ := cmd.Flags().GetString("out")
+			return cli.showHistory(q, output)
+		},
+	}
+
+	cmd.Flags().Uint64("from-block", 0, "the first block to filter")
+	cmd.Flags().Uint64("to-block", 0, "the last block to filter, default is the latest block")
+	cmd.Flags().String("direction", "all", "the direction of the transfers, all|in|out")
+	cmd.Flags().Uint64("chunk", token.DefaultHistoryChunk, "the initial number of blocks filtered by one request, halved if the request fails")
+	cmd.Flags().String("out", "", "the csv file to export the transfers")
+
+	return cmd
+}
+
+func (cli *CLI) showHistory(q token.HistoryQuery, output string) error {
+	tok, err := cli.GetToken()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	symbol, err := tok.Symbol(ctx)
+	if err != nil {
+		return rpcErrorf("Symbol: Get Symbol Error(%v)", err)
+	}
+	var decimals uint8
+	if cli.mode != cli.blockchain.ModeERC721() {
+		decimals, err = tok.Decimals(ctx)
+		if err != nil {
+			return rpcErrorf("Decimals: Get Decimals Error(%v)", err)
+		}
+	}
+
+	events, historyErr := tok.History(ctx, q, func(from, to uint64, found int) {
+		fmt.Fprintf(cli.stderr, "Filtered blocks %d-%d, found %d transfers\n", from, to, found)
+	})
+
+	result := historyJSON{
+		Address:   q.Account.String(),
+		FromBlock: q.FromBlock,
+		ToBlock:   q.ToBlock,
+		Output:    output,
+		Events:    make([]historyEventJSON, 0, len(events)),
+	}
+	for _, e := range events {
+		event := historyEventJSON{
+			BlockNumber: e.BlockNumber,
+			TxHash:      e.TxHash.String(),
+			LogIndex:    e.LogIndex,
+			Direction:   historyDirection(q.Account, e),
+			From:        e.From.String(),
+			To:          e.To.String(),
+		}
+		if e.Time > 0 {
+			event.Time = time.Unix(int64(e.Time), 0).UTC().Format(time.RFC3339)
+		}
+		if cli.mode == cli.blockchain.ModeERC721() {
+			event.TokenID = e.Value.String()
+		} else {
+			event.Amount = newAmountJSON(e.Value, decimals, symbol)
+		}
+		result.Events = append(result.Events, event)
+	}
+
+	for _, e := range result.Events {
+		value := "tokenID " + e.TokenID
+		if e.Amount != nil {
+			value = e.Amount.Text + " " + symbol
+		}
+		counterparty := e.To
+		if e.Direction == "in" {
+			counterparty = e.From
+		}
+		cli.printf("%s block %d %s %-4s %s %s\n", e.Time, e.BlockNumber, e.TxHash, e.Direction, counterparty, value)
+	}
+	cli.printf("Found %d transfers of %s\n", len(result.Events), result.Address)
+
+	if output != "" {
+		if err := writeHistoryCSV(output, result.Events); err != nil {
+			return newError(CategoryGeneral, "export to %s error: %v", output, err)
+		}
+		cli.printf("The transfers are exported to %s\n", output)
+	}
+
+	if cli.isJSON() {
+		cli.printJSON(result)
+	}
+
+	if historyErr != nil {
+		return rpcErrorf("%v", historyErr)
+	}
+
+	return nil
+}
+
+// historyDirection returns in, out or self of the transfer for account
+func historyDirection(account common.Address, e token.TransferEvent) string {
+	switch {
+	case e.From == account && e.To == account:
+		return "self"
+	case e.To == account:
+		return "in"
+	default:
+		return "out"
+	}
+}
+
+func writeHistoryCSV(output string, events []historyEventJSON) error {
+	file, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	w := csv.NewWriter(file)
+	if err := w.Write([]string{"blockNumber", "time", "txHash", "logIndex", "direction", "from", "to", "value", "tokenID"}); err != nil {
+		return err
+	}
+	for _, e := range events {
+		value := ""
+		if e.Amount != nil {
+			value = e.Amount.Text
+		}
+		record := []string{strconv.FormatUint(e.BlockNumber, 10), e.Time, e.TxHash, strconv.FormatUint(uint64(e.LogIndex), 10),
+			e.Direction, e.From, e.To, value, e.TokenID}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return err
+	}
+
+	return file.Close()
+}
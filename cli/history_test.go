@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/newtonproject/tokencommander/token"
+)
+
+func TestHistory(t *testing.T) {
+	cli := NewCLI()
+
+	cli.TestCommand("history")
+	cli.TestCommand("history 0xDC8F76075Db000Fa70fdA3AA2c95d63F22A10a67 --from-block 100 --to-block 200 --direction in")
+	cli.TestCommand("history 0xDC8F76075Db000Fa70fdA3AA2c95d63F22A10a67 --direction out --out history.csv --mode NRC7")
+}
+
+func TestHistoryDirection(t *testing.T) {
+	account := common.HexToAddress("0xDC8F76075Db000Fa70fdA3AA2c95d63F22A10a67")
+	other := common.HexToAddress("0x6a038842f9E9010624eAeB5f30ec5004C05EE21D")
+
+	tests := []struct {
+		from, to common.Address
+		want     string
+	}{
+		{account, other, "out"},
+		{other, account, "in"},
+		{account, account, "self"},
+	}
+	for _, tt := range tests {
+		if got := historyDirection(account, token.TransferEvent{From: tt.from, To: tt.to}); got != tt.want {
+			t.Errorf("historyDirection(%s, %s): want %s, got %s", tt.from.String(), tt.to.String(), tt.want, got)
+		}
+	}
+}
@@ -1,156 +1,278 @@
 package cli
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"io/ioutil"
 	"math/big"
-	"net/http"
-	"strconv"
 
-	"github.com/newtonproject/tokencommander/contracts/ERC20"
-	"github.com/newtonproject/tokencommander/contracts/ERC721"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/newtonproject/tokencommander/token"
 	"github.com/spf13/cobra"
 )
 
 func (cli *CLI) buildInfoCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:                   "info [-a contractAddress] [-s contractSymbol] [TokenID]",
+		Use:                   "info [-a contractAddress] [-s contractSymbol] [--roles [--from-block n]] [TokenID]",
 		Short:                 "Show contract basic info",
 		DisableFlagsInUseLine: true,
-		Run: func(cmd *cobra.Command, args []string) {
-			SimpleToken, err := cli.GetSimpleToken()
-			if err != nil {
-				fmt.Println("GetSimpleToken Error: ", err)
-				fmt.Println(cmd.UsageString())
-				return
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var resolver *token.MetadataResolver
+			if metadata, _ := cmd.Flags().GetBool("metadata"); metadata {
+				resolver = cli.metadataResolver(cmd)
 			}
-
-			fmt.Printf("The contract address(%s) basic information is as follows:\n", cli.contractAddress)
-
-			name, err := SimpleToken.Name(nil)
+			info, err := cli.getInfoJSON(args, resolver)
 			if err != nil {
-				fmt.Printf("Name: Get name Error(%v)\n", err)
-			} else {
-				fmt.Println("Name: ", name)
+				return err
+			}
+			if roles, _ := cmd.Flags().GetBool("roles"); roles && cli.mode != cli.blockchain.ModeERC721() {
+				fromBlock, _ := cmd.Flags().GetUint64("from-block")
+				if err := cli.countRoles(info, fromBlock); err != nil {
+					return err
+				}
 			}
 
-			symbol, err := SimpleToken.Symbol(nil)
-			if err != nil {
-				fmt.Printf("Symbol: Get symbol Error(%v)\n", err)
-			} else {
-				fmt.Println("Symbol: ", symbol)
+			if cli.isJSON() {
+				cli.printJSON(info)
+				return nil
 			}
+			cli.showInfo(info)
 
-			if cli.mode == ModeERC20 {
-				decimals, err := SimpleToken.(*ERC20.BaseToken).Decimals(nil)
-				if err != nil {
-					fmt.Printf("Decimals: Get decimals Error(%v)\n", err)
-				} else {
-					fmt.Println("Decimals: ", decimals)
-				}
+			return nil
+		},
+	}
 
-				totalSupply, err := SimpleToken.TotalSupply(nil)
-				if err != nil {
-					fmt.Printf("TotalSupply: Get totalSupply Error(%v)\n", err)
-				} else {
-					fmt.Println("TotalSupply: ", getAmountTextByWeiWithDecimals(totalSupply, decimals), symbol)
-				}
-			} else if cli.mode == ModeERC721 {
-				totalSupply, err := SimpleToken.TotalSupply(nil)
-				if err != nil {
-					fmt.Printf("TotalSupply: Get totalSupply Error(%v)\n", err)
-				} else {
-					fmt.Println("TotalSupply: ", totalSupply.String())
-				}
+	cmd.Flags().Bool("metadata", false, "show metadata info for tokenID")
+	cmd.Flags().Bool("roles", false, fmt.Sprintf("count the role members of %s by replaying the role logs, which are always counted for %s", cli.blockchain.ModeERC20(), cli.blockchain.ModeERC721()))
+	cmd.Flags().Uint64("from-block", 0, "the first block to replay the role logs, which should be no later than the deploy block of the contract")
+	addMetadataFlags(cmd)
 
-				if len(args) > 0 {
-					id, err := strconv.Atoi(args[0])
-					if err != nil {
-						fmt.Printf("TokenID: Get token ID error(%v)\n", err)
-						return
-					}
-					fmt.Printf("The info of token ID %d is as follows: \n", id)
-
-					idBig := big.NewInt(int64(id))
-
-					exists, err := SimpleToken.(*ERC721.NRC7Full).Exists(nil, idBig)
-					if err != nil {
-						fmt.Println("\tCheck token ID exists error: ", err)
-						return
-					}
-					if !exists {
-						fmt.Printf("\tToken ID %d not exists\n", id)
-						return
-					}
-
-					// owner
-					owner, err := SimpleToken.(*ERC721.NRC7Full).OwnerOf(nil, idBig)
-					if err != nil {
-						fmt.Printf("\tOwner: Get owner error(%v)\n", err)
-					} else {
-						fmt.Printf("\tOwner: %s\n", owner.String())
-					}
-
-					uri, err := SimpleToken.(*ERC721.NRC7Full).TokenURI(nil, idBig)
-					if err != nil {
-						fmt.Printf("\tTokenURI: Get token uri error(%v)\n", err)
-					} else {
-						fmt.Printf("\tTokenURI: %s\n", uri)
-
-						metadata, _ := cmd.Flags().GetBool("metadata")
-						if metadata {
-							// try to get metadata
-							raw, err := getJson(uri)
-							if err != nil {
-								// fmt.Println("\tMetadata: get metadata error", err) // ignore
-							} else {
-								rawStr, err := json.MarshalIndent(raw, "", "\t")
-								if err != nil {
-									fmt.Println("\tMetadata: ", err)
-								} else {
-									fmt.Println("\tMetadata:", string(rawStr))
-								}
-							}
-						}
-
-					}
+	return cmd
+}
 
-				}
+// countRoles counts the role members of NRC6 into info by replaying the
+// role logs from fromBlock, which are not in the batch of Info
+func (cli *CLI) countRoles(info *infoJSON, fromBlock uint64) error {
+	tok, err := cli.GetToken()
+	if err != nil {
+		return err
+	}
 
-			}
+	counts, err := tok.RoleCounts(context.Background(), fromBlock)
+	if err != nil {
+		info.RolesError = err.Error()
+		return nil
+	}
+	for _, r := range counts {
+		info.Roles = append(info.Roles, roleCountJSON{Name: r.Name, ID: hexutil.Encode(r.Role[:]), Members: r.Members})
+	}
 
-			return
-		},
+	return nil
+}
+
+func (cli *CLI) showInfo(info *infoJSON) {
+	cli.printf("The contract address(%s) basic information is as follows:\n", info.ContractAddress)
+	cli.println("Name: ", info.Name)
+	cli.println("Symbol: ", info.Symbol)
+	if cli.mode == cli.blockchain.ModeERC721() {
+		cli.println("TotalSupply: ", info.TotalSupply.Value)
+	} else {
+		cli.println("Decimals: ", info.Decimals)
+		cli.println("TotalSupply: ", info.TotalSupply.Text, info.Symbol)
+	}
+	if info.Cap != nil {
+		cli.println("Cap: ", info.Cap.Text, info.Symbol)
+	}
+	if info.Owner != "" {
+		cli.println("Owner: ", info.Owner)
+	}
+	if info.MintingFinished != nil {
+		cli.println("MintingFinished: ", *info.MintingFinished)
+	}
+	if info.TransferEnabled != nil {
+		cli.println("TransferEnabled: ", *info.TransferEnabled)
+	}
+	if info.Paused != nil {
+		cli.println("Paused: ", *info.Paused)
+	}
+	cli.println("CodeSize: ", info.CodeSize)
+	if len(info.Roles) > 0 {
+		cli.println("Roles:")
+		for _, r := range info.Roles {
+			cli.printf("\t%s: %d members\n", r.Name, r.Members)
+		}
+	}
+	if info.RolesError != "" {
+		cli.println("Roles: unknown, count the members error:", info.RolesError)
+	}
+	if len(info.Interfaces) > 0 {
+		cli.println("Interfaces:")
+		for _, i := range info.Interfaces {
+			cli.printf("\t%s(%s): %v\n", i.Name, i.ID, i.Supported)
+		}
 	}
 
-	cmd.Flags().Bool("metadata", false, "show metadata info for tokenID")
+	if info.Token == nil {
+		return
+	}
+	cli.printf("The info of token ID %s is as follows: \n", info.Token.TokenID)
+	if !info.Token.Exists {
+		cli.printf("\tToken ID %s not exists\n", info.Token.TokenID)
+		return
+	}
+	cli.printf("\tOwner: %s\n", info.Token.Owner)
+	cli.printf("\tTokenURI: %s\n", info.Token.TokenURI)
+	if len(info.Token.Metadata) > 0 {
+		rawStr, err := json.MarshalIndent(info.Token.Metadata, "", "\t")
+		if err != nil {
+			cli.println("\tMetadata: ", err)
+		} else {
+			cli.println("\tMetadata:", string(rawStr))
+		}
+	}
+	if info.Token.MetadataError != "" {
+		cli.println("\tMetadata error:", info.Token.MetadataError)
+	}
+	if len(info.Token.MetadataProblems) > 0 {
+		cli.println("\tMetadata problems:")
+		for _, p := range info.Token.MetadataProblems {
+			cli.printf("\t\t%s\n", p)
+		}
+	}
+}
 
-	return cmd
+type tokenInfoJSON struct {
+	TokenID  string          `json:"tokenID"`
+	Exists   bool            `json:"exists"`
+	Owner    string          `json:"owner,omitempty"`
+	TokenURI string          `json:"tokenURI,omitempty"`
+	Metadata json.RawMessage `json:"metadata,omitempty"`
+	// MetadataError is the error of resolving or fetching the metadata
+	MetadataError    string   `json:"metadataError,omitempty"`
+	MetadataProblems []string `json:"metadataProblems,omitempty"`
+}
+
+type roleCountJSON struct {
+	Name    string `json:"name"`
+	ID      string `json:"id"`
+	Members int    `json:"members"`
 }
 
-func getJson(uri string) (json.RawMessage, error) {
-	resp, err := http.Get(uri)
+type interfaceJSON struct {
+	Name      string `json:"name"`
+	ID        string `json:"id"`
+	Supported bool   `json:"supported"`
+}
+
+type infoJSON struct {
+	ContractAddress string          `json:"contractAddress"`
+	Mode            string          `json:"mode"`
+	Name            string          `json:"name"`
+	Symbol          string          `json:"symbol"`
+	Decimals        uint8           `json:"decimals"`
+	TotalSupply     *amountJSON     `json:"totalSupply"`
+	Cap             *amountJSON     `json:"cap,omitempty"`
+	Owner           string          `json:"owner,omitempty"`
+	MintingFinished *bool           `json:"mintingFinished,omitempty"`
+	TransferEnabled *bool           `json:"transferEnabled,omitempty"`
+	Paused          *bool           `json:"paused,omitempty"`
+	CodeSize        int             `json:"codeSize"`
+	Roles           []roleCountJSON `json:"roles"`
+	// RolesError is the error of counting the role members, with the roles
+	// empty but unknown
+	RolesError string          `json:"rolesError,omitempty"`
+	Interfaces []interfaceJSON `json:"interfaces"`
+	Token      *tokenInfoJSON  `json:"token,omitempty"`
+}
+
+// getInfoJSON returns the info of the token, and of the tokenID in args if
+// any, with the metadata fetched by resolver if not nil
+func (cli *CLI) getInfoJSON(args []string, resolver *token.MetadataResolver) (*infoJSON, error) {
+	tok, err := cli.GetToken()
 	if err != nil {
 		return nil, err
 	}
+	ctx := context.Background()
 
-	if resp.Body == nil {
-		return nil, errors.New("body nil")
+	info := &infoJSON{
+		ContractAddress: cli.contractAddress,
+		Mode:            cli.mode,
 	}
-	defer resp.Body.Close()
 
-	body, err := ioutil.ReadAll(resp.Body)
+	// fetch the report in one batch
+	var batcher token.BatchCaller
+	if cli.rpcClient != nil {
+		batcher = cli.rpcClient
+	}
+	report, err := tok.Info(ctx, batcher)
 	if err != nil {
-		return nil, err
+		return nil, rpcErrorf("Info: Get info Error(%v)", err)
 	}
 
-	var raw json.RawMessage
-	err = json.Unmarshal(body, &raw)
+	info.Name = report.Name
+	info.Symbol = report.Symbol
+	info.Decimals = report.Decimals
+	info.TotalSupply = newAmountJSON(report.TotalSupply, info.Decimals, info.Symbol)
+	if report.Cap != nil {
+		info.Cap = newAmountJSON(report.Cap, info.Decimals, info.Symbol)
+	}
+	if report.Owner != nil {
+		info.Owner = report.Owner.String()
+	}
+	info.MintingFinished = report.MintingFinished
+	info.TransferEnabled = report.TransferEnabled
+	info.Paused = report.Paused
+	info.CodeSize = report.CodeSize
+	info.Roles = make([]roleCountJSON, 0, len(report.Roles))
+	for _, r := range report.Roles {
+		info.Roles = append(info.Roles, roleCountJSON{Name: r.Name, ID: hexutil.Encode(r.Role[:]), Members: r.Members})
+	}
+	if report.RolesErr != nil {
+		info.RolesError = report.RolesErr.Error()
+	}
+	info.Interfaces = make([]interfaceJSON, 0, len(report.Interfaces))
+	for _, i := range report.Interfaces {
+		info.Interfaces = append(info.Interfaces, interfaceJSON{Name: i.Name, ID: hexutil.Encode(i.ID[:]), Supported: i.Supported})
+	}
+
+	if cli.mode != cli.blockchain.ModeERC721() || len(args) == 0 {
+		return info, nil
+	}
+
+	idBig, ok := big.NewInt(0).SetString(args[0], 10)
+	if !ok {
+		return nil, validationErrorf("TokenID: Get token ID error(%s)", args[0])
+	}
+	erc721 := tok.ERC721()
+	info.Token = &tokenInfoJSON{TokenID: idBig.String()}
+	info.Token.Exists, err = erc721.Exists(nil, idBig)
 	if err != nil {
-		return nil, err
+		return nil, rpcErrorf("Check token ID exists error: %v", err)
+	}
+	if !info.Token.Exists {
+		return info, nil
+	}
+
+	owner, err := erc721.OwnerOf(nil, idBig)
+	if err != nil {
+		return nil, rpcErrorf("Owner: Get owner error(%v)", err)
+	}
+	info.Token.Owner = owner.String()
+
+	info.Token.TokenURI, err = erc721.TokenURI(nil, idBig)
+	if err != nil {
+		return nil, rpcErrorf("TokenURI: Get token uri error(%v)", err)
+	}
+	if resolver != nil {
+		check := resolver.Check(context.Background(), idBig, info.Token.TokenURI)
+		info.Token.Metadata = metadataRaw(check)
+		if check.Err != nil {
+			info.Token.MetadataError = check.Err.Error()
+		}
+		for _, p := range check.Problems {
+			info.Token.MetadataProblems = append(info.Token.MetadataProblems, p.String())
+		}
 	}
 
-	return raw, nil
+	return info, nil
 }
@@ -6,4 +6,5 @@ func TestInfo(t *testing.T) {
 	cli := NewCLI()
 
 	cli.TestCommand("info")
+	cli.TestCommand("info --roles --from-block 1000000")
 }
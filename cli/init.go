@@ -5,9 +5,7 @@ import (
 	"strings"
 
 	"github.com/ethereum/go-ethereum/accounts/keystore"
-	prompt2 "github.com/ethereum/go-ethereum/console/prompt"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 func (cli *CLI) buildInitCmd() *cobra.Command {
@@ -15,47 +13,47 @@ func (cli *CLI) buildInitCmd() *cobra.Command {
 		Use:                   "init",
 		Short:                 "Initialize config file",
 		DisableFlagsInUseLine: true,
-		Run: func(cmd *cobra.Command, args []string) {
+		RunE: func(cmd *cobra.Command, args []string) error {
 
-			fmt.Println("Initialize config file")
+			cli.println("Initialize config file")
 
 			prompt := fmt.Sprintf("Enter file in which to save (%s): ", defaultConfigFile)
-			configPath, err := prompt2.Stdin.PromptInput(prompt)
+			configPath, err := cli.promptInput(prompt)
 
 			if err != nil {
-				fmt.Println("PromptInput err:", err)
+				cli.println("PromptInput err:", err)
 			}
 			if configPath == "" {
 				configPath = defaultConfigFile
 			}
 			cli.config = configPath
 
-			walletPathV := viper.GetString("walletPath")
+			walletPathV := cli.v.GetString("walletPath")
 			prompt = fmt.Sprintf("Enter the wallet storage directory (%s): ", walletPathV)
-			cli.walletPath, err = prompt2.Stdin.PromptInput(prompt)
+			cli.walletPath, err = cli.promptInput(prompt)
 			if err != nil {
-				fmt.Println("PromptInput err:", err)
+				cli.println("PromptInput err:", err)
 			}
 			if cli.walletPath == "" {
 				cli.walletPath = walletPathV
 			}
-			viper.Set("walletPath", cli.walletPath)
+			cli.v.Set("walletPath", cli.walletPath)
 
-			rpcURLV := viper.GetString("rpcURL")
+			rpcURLV := cli.v.GetString("rpcURL")
 			prompt = fmt.Sprintf("Enter %s json rpc or ipc url (%s): ", cli.blockchain.String(), rpcURLV)
-			cli.rpcURL, err = prompt2.Stdin.PromptInput(prompt)
+			cli.rpcURL, err = cli.promptInput(prompt)
 			if err != nil {
-				fmt.Println("PromptInput err:", err)
+				cli.println("PromptInput err:", err)
 			}
 			if cli.rpcURL == "" {
 				cli.rpcURL = rpcURLV
 			}
-			viper.Set("rpcURL", cli.rpcURL)
+			cli.v.Set("rpcURL", cli.rpcURL)
 
 			prompt = fmt.Sprintf("Create a default account or not: [Y/n] ")
-			createNewAddress, err := prompt2.Stdin.PromptInput(prompt)
+			createNewAddress, err := cli.promptInput(prompt)
 			if err != nil {
-				fmt.Println("PromptInput err:", err)
+				cli.println("PromptInput err:", err)
 			}
 			if len(createNewAddress) <= 0 {
 				createNewAddress = "Y"
@@ -65,32 +63,33 @@ func (cli *CLI) buildInitCmd() *cobra.Command {
 				wallet := keystore.NewKeyStore(cli.walletPath,
 					keystore.LightScryptN, keystore.LightScryptP)
 
-				cli.walletPassword, err = getPassPhrase("Your new account is locked with a password. Please give a password. Do not forget this password.", true)
+				cli.walletPassword, err = cli.getPassPhrase("Your new account is locked with a password. Please give a password. Do not forget this password.", true)
 				if err == nil {
 					account, err := wallet.NewAccount(cli.walletPassword)
 					if err == nil {
 						baseAddress := account.Address.String()
-						fmt.Println("New accout is ", baseAddress)
-						viper.Set("from", baseAddress)
+						cli.println("New accout is ", baseAddress)
+						cli.v.Set("from", baseAddress)
 
-						getFaucet(cli.rpcURL, baseAddress)
+						cli.getFaucet(baseAddress)
 
 					} else {
-						fmt.Println("Account error:", err)
-						fmt.Println("Just create your account later.")
+						cli.println("Account error:", err)
+						cli.println("Just create your account later.")
 					}
 				} else {
-					fmt.Println("Error: ", err)
-					fmt.Println("Just create your account later.")
+					cli.println("Error: ", err)
+					cli.println("Just create your account later.")
 				}
 			}
 
-			err = viper.WriteConfigAs(configPath)
+			err = cli.v.WriteConfigAs(configPath)
 			if err != nil {
-				fmt.Println("WriteConfig:", err)
-			} else {
-				fmt.Println("Your configuration has been saved in ", configPath)
+				return configErrorf("WriteConfig: %v", err)
 			}
+			cli.println("Your configuration has been saved in ", configPath)
+
+			return nil
 		},
 	}
 
@@ -3,139 +3,290 @@ package cli
 import (
 	"context"
 	"fmt"
-	"os"
-	"strings"
 	"time"
 
-	"github.com/ethereum/go-ethereum/accounts/abi"
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/newtonproject/tokencommander/contracts/ERC721"
+	"github.com/newtonproject/tokencommander/token"
 	"github.com/spf13/cobra"
 )
 
-var MinterRole = crypto.Keccak256([]byte("MINTER_ROLE")) // 0x9f2df0fed2c77648de5860a4cc508cd0818c85b8b8a1ab4ceeef8d981c8956a6}
+// MinterRole is the keccak256 hash of MINTER_ROLE
+var MinterRole = token.MinterRole.Bytes()
+
+type mintJSON struct {
+	To       string   `json:"to"`
+	TokenURI string   `json:"tokenURI,omitempty"`
+	TokenIDs []string `json:"tokenIDs"`
+	Tx       *txJSON  `json:"tx"`
+}
+
+type mintAmountJSON struct {
+	To          string      `json:"to"`
+	Amount      *amountJSON `json:"amount"`
+	TotalSupply *amountJSON `json:"totalSupply,omitempty"`
+	Tx          *txJSON     `json:"tx"`
+}
+
+type mintFinishJSON struct {
+	MintingFinished bool    `json:"mintingFinished"`
+	Tx              *txJSON `json:"tx"`
+}
 
 func (cli *CLI) buildMintCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:                   "mint <address> [--uri <tokenUri>]",
-		Short:                 fmt.Sprintf("Command to mint tokenID for address, only for %s", ModeERC721),
-		Args:                  cobra.MinimumNArgs(1),
+		Use:                   "mint <address> [amount] [--uri <tokenUri>] | mint --batch <drop.csv> [--out <minted.csv>]",
+		Short:                 "Command to mint amount or tokenID for address",
 		Aliases:               []string{"mine"},
 		DisableFlagsInUseLine: true,
-		Run: func(cmd *cobra.Command, args []string) {
+		RunE: func(cmd *cobra.Command, args []string) error {
 
-			if cli.mode != ModeERC721 {
-				fmt.Println(errOnlyERC721)
-				return
+			if cli.address == "" || !common.IsHexAddress(cli.address) {
+				return configErrorf("not set from address of owner or from address illegal")
 			}
 
-			simpleToken, err := cli.GetSimpleToken()
-			if err != nil {
-				fmt.Println(err)
-				return
+			if cmd.Flags().Changed("batch") {
+				batchFileName, _ := cmd.Flags().GetString("batch")
+				return cli.mintBatch(cmd, batchFileName)
 			}
-			erc721Token := simpleToken.(*ERC721.NRC7Full)
 
-			parsed, err := abi.JSON(strings.NewReader(ERC721.NRC7FullABI))
-			if err != nil {
-				fmt.Println(err)
-				return
+			if len(args) < 1 {
+				fmt.Fprint(cli.stderr, cmd.UsageString())
+				return validationErrorf("the address of token owner not set")
+			}
+			toAddressStr := args[0]
+			if toAddressStr == "" || !common.IsHexAddress(toAddressStr) {
+				fmt.Fprint(cli.stderr, cmd.UsageString())
+				return validationErrorf("the address of token owner illegal")
 			}
-			contractAddress := common.HexToAddress(cli.contractAddress)
-			contract := bind.NewBoundContract(contractAddress, parsed, cli.client, cli.client, cli.client)
+			toAddress := common.HexToAddress(toAddressStr)
+
+			if cli.mode != cli.blockchain.ModeERC721() {
+				if len(args) < 2 {
+					fmt.Fprint(cli.stderr, cmd.UsageString())
+					return validationErrorf("the amount to mint not set")
+				}
+				return cli.mintAmount(toAddress, args[1])
+			}
+
+			var tokenUri string
+			if cmd.Flags().Changed("uri") {
+				tokenUri, _ = cmd.Flags().GetString("uri")
+			} else if cmd.Flags().Changed("url") {
+				tokenUri, _ = cmd.Flags().GetString("url")
+			}
+
+			return cli.mintTokenID(toAddress, tokenUri)
+		},
+	}
+
+	cmd.Flags().String("uri", "", fmt.Sprintf("mint with token uri, only for %s", cli.blockchain.ModeERC721()))
+	cmd.Flags().String("url", "", "mint with token url")
+	cmd.Flags().MarkDeprecated("url", "use --uri instead")
+	cmd.Flags().String("batch", "", fmt.Sprintf("mint the tokenIDs for the address[,uri] rows of the csv file, only for %s", cli.blockchain.ModeERC721()))
+	cmd.Flags().String("out", "", "the csv file to write the minted tokenID and tx hash of each row, the rows pending or success in it are skipped, default is <batch>.minted.csv")
+	cmd.Flags().Uint64P("nonce", "n", 0, "the number of nonce to start of the batch")
 
+	cmd.AddCommand(cli.buildMintFinishCmd())
+
+	return cmd
+}
+
+func (cli *CLI) mintTokenID(toAddress common.Address, tokenUri string) error {
+	tok, err := cli.GetToken()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+	defer cancel()
+
+	// refuse before unlocking the wallet if the collection is paused
+	if err := tok.CheckNotPaused(ctx); err != nil {
+		return tokenErrorf(CategoryRPC, "%w", err)
+	}
+
+	opts, err := cli.getTransactOpts(cli.address)
+	if err != nil {
+		return err
+	}
+	opts.Context = ctx
+
+	tx, err := tok.Mint(opts, toAddress, tokenUri)
+	if err != nil {
+		return tokenErrorf(CategoryRPC, "mint error(%w)", err)
+	}
+
+	cli.printf("Succeed mint token for address %s, TxID %s.\n", toAddress.String(), tx.Hash().String())
+	cli.println("Waiting for transaction to be mined...")
+	receipt, err := token.WaitMined(ctx, cli.client, tx)
+	if err != nil {
+		return rpcErrorf("WaitMined error: %v", err)
+	}
+	if !receipt.Succeeded() {
+		return revertedErrorf("the tx %s is confirmed but status is failed", tx.Hash().String())
+	}
+
+	tokenIDs, err := tok.MintedTokenIDs(receipt)
+	if err != nil {
+		return newError(CategoryGeneral, "%v", err)
+	}
+	for _, tokenID := range tokenIDs {
+		cli.println("The tokenID is: ", tokenID.String())
+	}
+
+	if cli.isJSON() {
+		result := mintJSON{
+			To:       toAddress.String(),
+			TokenURI: tokenUri,
+			TokenIDs: make([]string, 0, len(tokenIDs)),
+			Tx:       newTxJSON(tx, receipt),
+		}
+		for _, tokenID := range tokenIDs {
+			result.TokenIDs = append(result.TokenIDs, tokenID.String())
+		}
+		cli.printJSON(result)
+	}
+
+	return nil
+}
+
+func (cli *CLI) mintAmount(toAddress common.Address, amountStr string) error {
+	tok, err := cli.GetToken()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+	defer cancel()
+
+	symbol, err := tok.Symbol(ctx)
+	if err != nil {
+		return rpcErrorf("Symbol: Get Symbol Error(%v)", err)
+	}
+	decimals, err := tok.Decimals(ctx)
+	if err != nil {
+		return rpcErrorf("Decimals: Get decimals Error(%v)", err)
+	}
+
+	amount, err := token.ParseAmount(amountStr, decimals)
+	if err != nil {
+		return validationErrorf("%v", err)
+	}
+
+	opts, err := cli.getTransactOpts(cli.address)
+	if err != nil {
+		return err
+	}
+	opts.Context = ctx
+
+	cli.printf("Try to mint %s %s for %s ...\n", getAmountTextByWeiWithDecimals(amount, decimals), symbol, toAddress.String())
+	tx, err := tok.MintAmount(opts, toAddress, amount)
+	if err != nil {
+		return tokenErrorf(CategoryRPC, "mint error(%w)", err)
+	}
+	cli.printf("Succeed submit mint, TxID %s.\n", tx.Hash().String())
+
+	receipt, err := cli.waitMined(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	result := mintAmountJSON{
+		To:     toAddress.String(),
+		Amount: newAmountJSON(amount, decimals, symbol),
+		Tx:     newTxJSON(tx, receipt),
+	}
+	if receipt.Succeeded() {
+		totalSupply, err := tok.TotalSupply(ctx)
+		if err != nil {
+			return rpcErrorf("TotalSupply: Get totalSupply Error(%v)", err)
+		}
+		result.TotalSupply = newAmountJSON(totalSupply, decimals, symbol)
+		cli.printf("The total supply is %s %s now\n", result.TotalSupply.Text, symbol)
+	}
+
+	if cli.isJSON() {
+		cli.printJSON(result)
+	}
+
+	if !receipt.Succeeded() {
+		return revertedErrorf("the tx %s is confirmed but status is failed", tx.Hash().String())
+	}
+
+	return nil
+}
+
+func (cli *CLI) buildMintFinishCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "finish [--confirm symbol]",
+		Short:                 fmt.Sprintf("Finish minting permanently, only for %s", cli.blockchain.ModeERC20()),
+		Args:                  cobra.NoArgs,
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cli.mode != cli.blockchain.ModeERC20() {
+				return validationErrorf("%v", cli.blockchain.errOnlyERC20())
+			}
 			if cli.address == "" || !common.IsHexAddress(cli.address) {
-				fmt.Println("Error: not set from address of owner or from address illegal")
-				return
+				return configErrorf("not set from address of owner or from address illegal")
 			}
 
-			var MinterRole32 [32]byte
-			copy(MinterRole32[:], MinterRole[:])
-			isMinter, err := erc721Token.HasRole(nil, MinterRole32, common.HexToAddress(cli.address))
+			tok, err := cli.GetToken()
 			if err != nil {
-				fmt.Printf("Error: check minter error(%s)\n", err)
-				return
-			}
-			if !isMinter {
-				fmt.Printf("The from address(%s) is not minter\n", cli.address)
-				return
+				return err
 			}
+			ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+			defer cancel()
 
-			toAddressStr := args[0]
-			if toAddressStr == "" || !common.IsHexAddress(toAddressStr) {
-				fmt.Println("Error: the address of token owner illegal")
-				fmt.Fprint(os.Stderr, cmd.UsageString())
-				return
+			symbol, err := tok.Symbol(ctx)
+			if err != nil {
+				return rpcErrorf("Symbol: Get Symbol Error(%v)", err)
+			}
+			finished, err := tok.MintingFinished(ctx)
+			if err != nil {
+				return rpcErrorf("MintingFinished: Get minting finished Error(%v)", err)
+			}
+			if finished {
+				return tokenErrorf(CategoryValidation, "%w", token.ErrMintingFinished)
 			}
-			toAddress := common.HexToAddress(toAddressStr)
 
-			var tokenUri string
-			if cmd.Flags().Changed("uri") {
-				tokenUri, err = cmd.Flags().GetString("uri")
-				if err != nil {
-					fmt.Println("Get token url error: ", err)
-					return
-				}
+			if err := cli.confirmTyped(cmd, fmt.Sprintf("Finish minting of %s", symbol), symbol); err != nil {
+				return err
 			}
 
 			opts, err := cli.getTransactOpts(cli.address)
 			if err != nil {
-				fmt.Println("GetTransactOpts: ", err)
-				return
+				return err
 			}
-			ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
-			defer cancel()
 			opts.Context = ctx
 
-			var tx *types.Transaction
-			if tokenUri == "" {
-				tx, err = erc721Token.Mint(opts, toAddress)
-				if err != nil {
-					fmt.Printf("Error: mint error(%s)\n", err)
-					return
-				}
-			} else {
-				tx, err = erc721Token.MintWithTokenURI(opts, toAddress, tokenUri)
-				if err != nil {
-					fmt.Printf("Error: mint error(%s)\n", err)
-					return
-				}
+			tx, err := tok.FinishMinting(opts)
+			if err != nil {
+				return tokenErrorf(CategoryRPC, "finish minting error(%w)", err)
 			}
+			cli.printf("Succeed submit finish minting, TxID %s.\n", tx.Hash().String())
 
-			fmt.Printf("Succeed mint token for address %s, TxID %s.\n", toAddress.String(), tx.Hash().String())
-			fmt.Println("Waiting for transaction to be mined...")
-			txr, err := bind.WaitMined(ctx, cli.client, tx)
+			receipt, err := cli.waitMined(ctx, tx)
 			if err != nil {
-				fmt.Println(err)
-				return
-			}
-			// txr.Logs
-
-			if len(txr.Logs) > 0 {
-				log := *(txr.Logs[0])
-				var transferLog ERC721.NRC7FullTransfer
-				err = contract.UnpackLog(&transferLog, "Transfer", log)
-				if err != nil {
-					fmt.Println("Unpack Log error: ", err)
-					return
-				}
+				return err
+			}
 
-				if transferLog.From != (common.Address{}) {
-					fmt.Println("From address from mint log is not zero")
-					return
-				}
+			if cli.isJSON() {
+				cli.printJSON(mintFinishJSON{
+					MintingFinished: receipt.Succeeded(),
+					Tx:              newTxJSON(tx, receipt),
+				})
+			}
 
-				fmt.Println("The tokenID is: ", transferLog.TokenId.String())
+			if !receipt.Succeeded() {
+				return revertedErrorf("the tx %s is confirmed but status is failed", tx.Hash().String())
 			}
+			cli.printf("The minting of %s is finished\n", symbol)
 
-			return
+			return nil
 		},
 	}
 
-	cmd.Flags().String("url", "", "mint with token url")
+	cmd.Flags().String("confirm", "", "the token symbol to confirm without prompt")
 
 	return cmd
 }
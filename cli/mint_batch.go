@@ -0,0 +1,321 @@
+package cli
+
+import (
+	"context"
+	"encoding/csv"
+	"math/big"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/newtonproject/tokencommander/token"
+	"github.com/spf13/cobra"
+)
+
+type mintBatchTxJSON struct {
+	Row      int      `json:"row"`
+	To       string   `json:"to"`
+	TokenURI string   `json:"tokenURI,omitempty"`
+	TokenIDs []string `json:"tokenIDs"`
+	Tx       *txJSON  `json:"tx"`
+}
+
+type mintBatchJSON struct {
+	From         string            `json:"from"`
+	Output       string            `json:"output"`
+	Transactions []mintBatchTxJSON `json:"transactions"`
+	GasTotal     string            `json:"gasTotal"`
+}
+
+// mintBatchOutput returns the default output file of the batch file, such
+// as drop.minted.csv of drop.csv
+func mintBatchOutput(batchFileName string) string {
+	return strings.TrimSuffix(batchFileName, filepath.Ext(batchFileName)) + ".minted.csv"
+}
+
+// mintBatchHeader is the header of the output file of mint --batch
+var mintBatchHeader = []string{"row", "address", "uri", "tokenID", "txHash", "status"}
+
+// The status of the rows in the output file of mint --batch
+const (
+	mintStatusNotSent = "not sent"
+	mintStatusPending = "pending"
+	mintStatusSuccess = "success"
+	mintStatusFailed  = "failed"
+)
+
+// mintBatch mints the tokenIDs for the address[,uri] rows of the csv file
+// with sequential nonces and one unlock of the wallet. The minted tokenID
+// and tx hash of each row are written to the output file as soon as the tx
+// is broadcast and mined, and the rows recorded pending or success in the
+// output file by the last run are skipped.
+func (cli *CLI) mintBatch(cmd *cobra.Command, batchFileName string) error {
+	if cli.mode != cli.blockchain.ModeERC721() {
+		return validationErrorf("%v", cli.blockchain.errOnlyERC721())
+	}
+
+	rows, err := readCSVRows(batchFileName, "address", "[uri]")
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return validationErrorf("no address row in %s", batchFileName)
+	}
+
+	output := mintBatchOutput(batchFileName)
+	if cmd.Flags().Changed("out") {
+		output, _ = cmd.Flags().GetString("out")
+	}
+
+	records, err := readMintBatchOutput(output, rows)
+	if err != nil {
+		return err
+	}
+	// the indexes of the rows to mint
+	var indexes []int
+	for i, record := range records {
+		if status := record[5]; status != mintStatusPending && status != mintStatusSuccess {
+			indexes = append(indexes, i)
+		}
+	}
+	if skipped := len(rows) - len(indexes); skipped > 0 {
+		cli.printf("Skip %d rows already minted or pending in %s\n", skipped, output)
+	}
+	if len(indexes) == 0 {
+		cli.printf("All the rows of %s are minted\n", batchFileName)
+		return nil
+	}
+
+	if err := cli.BuildClient(); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	chainID, err := cli.client.NetworkID(ctx)
+	if err != nil {
+		return rpcErrorf("NetworkID Error: %v", err)
+	}
+
+	mints := make([]token.NFTMint, 0, len(indexes))
+	for _, i := range indexes {
+		row := rows[i]
+		to, err := cli.parseBatchAddress(chainID, row.Fields[0])
+		if err != nil {
+			return validationErrorf("row %d: %v", row.Row, err)
+		}
+		if to == (common.Address{}) {
+			return validationErrorf("row %d: the address to mint for is zero address", row.Row)
+		}
+
+		mints = append(mints, token.NFTMint{To: to, TokenURI: row.Fields[1]})
+	}
+
+	tok, err := cli.GetToken()
+	if err != nil {
+		return err
+	}
+	address := common.HexToAddress(cli.address)
+
+	// refuse before unlocking the wallet
+	if err := tok.CheckBatchMint(ctx, address); err != nil {
+		return tokenErrorf(CategoryRPC, "%w", err)
+	}
+
+	nonce := uint64(0)
+	if cmd.Flags().Changed("nonce") {
+		nonce, _ = cmd.Flags().GetUint64("nonce")
+	} else {
+		nonce, err = cli.client.PendingNonceAt(ctx, address)
+		if err != nil {
+			return rpcErrorf("PendingNonceAt error: %v", err)
+		}
+	}
+
+	cli.printf("Try to mint %d tokenIDs from %s with nonce %d ...\n", len(mints), batchFileName, nonce)
+
+	opts, err := cli.getBatchTransactOpts(address.String())
+	if err != nil {
+		return err
+	}
+	opts.Context = ctx
+
+	// stop broadcasting on interrupt, the rows broadcast are recorded
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(interrupt)
+	go func() {
+		select {
+		case <-interrupt:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	total := len(mints)
+	gasTotal := big.NewInt(0)
+	failed := 0
+	mined := 0
+	var saveErr error
+	results, batchErr := tok.BatchMint(opts, mints, nonce, func(r *token.BatchMintResult) {
+		i := indexes[r.Nonce-nonce]
+		records[i] = mintBatchRecord(rows[i], r)
+		// stop minting if the row can not be recorded
+		if err := writeMintBatchOutput(output, records); err != nil && saveErr == nil {
+			saveErr = err
+			cancel()
+		}
+
+		if r.Receipt == nil {
+			cli.printf("[%d/%d] Succeed broadcast mint for %s with nonce %d, TxID %s.\n",
+				r.Nonce-nonce+1, total, r.To.String(), r.Nonce, r.Tx.Hash().String())
+			return
+		}
+
+		mined++
+		gasTotal.Add(gasTotal, r.Receipt.GasFee)
+		if !r.Receipt.Succeeded() {
+			failed++
+			cli.printf("[%d/%d] Succeed mined txID %s but status failed.\n", mined, total, r.Tx.Hash().String())
+			return
+		}
+		for _, tokenID := range r.TokenIDs {
+			cli.printf("[%d/%d] Succeed mined txID %s, the tokenID is %s.\n", mined, total, r.Tx.Hash().String(), tokenID.String())
+		}
+	})
+	if batchErr != nil {
+		batchErr = tokenErrorf(CategoryRPC, "%w", batchErr)
+		cli.printf("Broadcast %d of %d transactions: %v\n", len(results), len(mints), batchErr)
+		cli.printf("Run again to mint the rows not sent, the rows recorded pending or success in %s are skipped\n", output)
+	}
+
+	if err := writeMintBatchOutput(output, records); err != nil && saveErr == nil {
+		saveErr = err
+	}
+	if saveErr != nil {
+		cli.printf("Write the minted tokenIDs to %s error: %v\n", output, saveErr)
+		if batchErr == nil {
+			batchErr = newError(CategoryGeneral, "write %s error: %v", output, saveErr)
+		}
+	} else {
+		cli.printf("The minted tokenIDs are written to %s\n", output)
+	}
+
+	cli.printf("Total Gas is: %s %s\n", cli.blockchain.getWeiAmountTextByUnit(gasTotal, cli.blockchain.UnitETH()), cli.blockchain.UnitETH())
+
+	if cli.isJSON() {
+		result := mintBatchJSON{
+			From:         address.String(),
+			Output:       output,
+			Transactions: make([]mintBatchTxJSON, 0, len(results)),
+			GasTotal:     gasTotal.String(),
+		}
+		for i, r := range results {
+			result.Transactions = append(result.Transactions, mintBatchTxJSON{
+				Row:      rows[indexes[i]].Row,
+				To:       r.To.String(),
+				TokenURI: r.TokenURI,
+				TokenIDs: tokenIDStrings(r.TokenIDs),
+				Tx:       newTxJSON(r.Tx, r.Receipt),
+			})
+		}
+		cli.printJSON(result)
+	}
+
+	if batchErr != nil {
+		return batchErr
+	}
+	if failed > 0 {
+		return revertedErrorf("%d of %d transactions mined but status failed", failed, len(results))
+	}
+
+	return nil
+}
+
+func tokenIDStrings(tokenIDs []*big.Int) []string {
+	s := make([]string, 0, len(tokenIDs))
+	for _, tokenID := range tokenIDs {
+		s = append(s, tokenID.String())
+	}
+	return s
+}
+
+// mintBatchRecord returns the output record of row with the result r, or
+// not sent if r is nil
+func mintBatchRecord(row csvRow, r *token.BatchMintResult) []string {
+	record := []string{strconv.Itoa(row.Row), row.Fields[0], row.Fields[1], "", "", mintStatusNotSent}
+	if r == nil {
+		return record
+	}
+
+	record[3] = strings.Join(tokenIDStrings(r.TokenIDs), ";")
+	record[4] = r.Tx.Hash().String()
+	switch {
+	case r.Receipt == nil:
+		record[5] = mintStatusPending
+	case r.Receipt.Succeeded():
+		record[5] = mintStatusSuccess
+	default:
+		record[5] = mintStatusFailed
+	}
+	return record
+}
+
+// readMintBatchOutput returns the output records of rows, which are read
+// from the output file written by the last run if it exists, or not sent.
+// The rows recorded should be the same rows of the batch file.
+func readMintBatchOutput(output string, rows []csvRow) ([][]string, error) {
+	records := make([][]string, 0, len(rows))
+	byRow := make(map[int]int, len(rows))
+	for i, row := range rows {
+		records = append(records, mintBatchRecord(row, nil))
+		byRow[row.Row] = i
+	}
+
+	if _, err := os.Stat(output); os.IsNotExist(err) {
+		return records, nil
+	}
+	recorded, err := readCSVRows(output, mintBatchHeader...)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range recorded {
+		row, err := strconv.Atoi(r.Fields[0])
+		i, ok := byRow[row]
+		if err != nil || !ok || rows[i].Fields[0] != r.Fields[1] || rows[i].Fields[1] != r.Fields[2] {
+			return nil, validationErrorf("the row %s of %s is not the row of the batch file, set another file by --out", r.Fields[0], output)
+		}
+		records[i] = r.Fields
+	}
+
+	return records, nil
+}
+
+// writeMintBatchOutput writes the records of the rows of the batch file to
+// output, by a temporary file renamed so output is never half written
+func writeMintBatchOutput(output string, records [][]string) error {
+	tmp := output + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+	defer file.Close()
+
+	w := csv.NewWriter(file)
+	if err := w.Write(mintBatchHeader); err != nil {
+		return err
+	}
+	if err := w.WriteAll(records); err != nil {
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, output)
+}
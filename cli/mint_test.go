@@ -1,10 +1,98 @@
 package cli
 
-import "testing"
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
 
 func TestMint(t *testing.T) {
 	cli := NewCLI()
 
 	cli.TestCommand("mint 1 0x8bBc8efCE7Ac8CC7F3D954d2966C8e92E66eE4A8")
+	cli.TestCommand("mint 0x8bBc8efCE7Ac8CC7F3D954d2966C8e92E66eE4A8 100.5")
+	cli.TestCommand("mint finish --confirm MT")
 
 }
+
+func TestMintBatch(t *testing.T) {
+	cli := NewCLI()
+
+	cli.TestCommand("mint --batch drop.csv --mode NRC7")
+	cli.TestCommand("mint --batch drop.csv --out drop.result.csv --nonce 10 --mode NRC7")
+
+	if got := mintBatchOutput("drops/drop.csv"); got != "drops/drop.minted.csv" {
+		t.Errorf("mintBatchOutput: want drops/drop.minted.csv, got %s", got)
+	}
+}
+
+func TestMintBatchOutputResume(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mintbatch")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	output := filepath.Join(dir, "drop.minted.csv")
+
+	rows := []csvRow{
+		{Row: 2, Fields: []string{"0xDC8F76075Db000Fa70fdA3AA2c95d63F22A10a67", "https://example.com/1.json"}},
+		{Row: 3, Fields: []string{"0x6a038842f9E9010624eAeB5f30ec5004C05EE21D", ""}},
+		{Row: 5, Fields: []string{"0xeF0b04a14e62434a99C4aF28C6dAb52ba9B1C8F3", ""}},
+	}
+
+	records, err := readMintBatchOutput(output, rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, record := range records {
+		if record[5] != mintStatusNotSent {
+			t.Errorf("readMintBatchOutput: want not sent without output, got %v", record)
+		}
+	}
+
+	// the run interrupted after the first row mined and the second broadcast
+	records[0] = []string{"2", rows[0].Fields[0], rows[0].Fields[1], "1", "0x01", mintStatusSuccess}
+	records[1] = []string{"3", rows[1].Fields[0], "", "", "0x02", mintStatusPending}
+	if err := writeMintBatchOutput(output, records); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(output + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("writeMintBatchOutput: want the temporary file removed, got %v", err)
+	}
+
+	resumed, err := readMintBatchOutput(output, rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(resumed, records) {
+		t.Errorf("readMintBatchOutput: want %v, got %v", records, resumed)
+	}
+
+	// the batch file changed after the last run
+	rows[1].Fields[0] = "0x8bBc8efCE7Ac8CC7F3D954d2966C8e92E66eE4A8"
+	if _, err := readMintBatchOutput(output, rows); ExitCode(err) != int(CategoryValidation) {
+		t.Errorf("readMintBatchOutput: want validation error of the changed row, got %v", err)
+	}
+}
+
+func TestConfirmTyped(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	cli := NewCLI().SetOutput(&stdout, &stderr).SetInput(strings.NewReader("MT\nmt\n"))
+	cmd := cli.buildMintFinishCmd()
+
+	if err := cli.confirmTyped(cmd, "Finish minting of MT", "MT"); err != nil {
+		t.Errorf("confirm MT: %v", err)
+	}
+	if err := cli.confirmTyped(cmd, "Finish minting of MT", "MT"); ExitCode(err) != int(CategoryValidation) {
+		t.Errorf("confirm mt: want validation error, got %v", err)
+	}
+
+	cmd.Flags().Set("confirm", "MT")
+	if err := cli.confirmTyped(cmd, "Finish minting of MT", "MT"); err != nil {
+		t.Errorf("confirm by flag: %v", err)
+	}
+}
@@ -0,0 +1,366 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/newtonproject/tokencommander/token"
+	"github.com/spf13/cobra"
+)
+
+type nftApproveJSON struct {
+	TokenID  string  `json:"tokenID"`
+	Owner    string  `json:"owner"`
+	Approved string  `json:"approved"`
+	Tx       *txJSON `json:"tx,omitempty"`
+}
+
+type nftOperatorJSON struct {
+	Owner    string  `json:"owner"`
+	Operator string  `json:"operator"`
+	Approved bool    `json:"approved"`
+	Tx       *txJSON `json:"tx,omitempty"`
+}
+
+func (cli *CLI) buildNFTCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "nft [approve|operator|uri|list|metadata]",
+		Short: fmt.Sprintf("Manage the tokens of %s", cli.blockchain.ModeERC721()),
+		Args:  cobra.MinimumNArgs(1),
+		RunE:  cli.unknownCommand,
+	}
+
+	cmd.AddCommand(cli.buildNFTApproveCmd())
+	cmd.AddCommand(cli.buildNFTOperatorCmd())
+	cmd.AddCommand(cli.buildNFTURICmd())
+	cmd.AddCommand(cli.buildNFTListCmd())
+	cmd.AddCommand(cli.buildNFTMetadataCmd())
+
+	return cmd
+}
+
+func parseTokenID(s string) (*big.Int, error) {
+	tokenID, ok := big.NewInt(0).SetString(s, 10)
+	if !ok || tokenID.Sign() < 0 {
+		return nil, validationErrorf("illegal tokenID %s", s)
+	}
+	return tokenID, nil
+}
+
+func (cli *CLI) buildNFTApproveCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "approve [show|set|clear]",
+		Short: "Manage the approved address of tokenID",
+		Args:  cobra.MinimumNArgs(1),
+		RunE:  cli.unknownCommand,
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:                   "show <tokenID>",
+		Short:                 "show the approved address of tokenID",
+		Args:                  cobra.MinimumNArgs(1),
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cli.mode != cli.blockchain.ModeERC721() {
+				return validationErrorf("%v", cli.blockchain.errOnlyERC721())
+			}
+			tokenID, err := parseTokenID(args[0])
+			if err != nil {
+				return err
+			}
+
+			return cli.showApproval(context.Background(), tokenID, nil, nil)
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:                   "set <tokenID> <address>",
+		Short:                 "approve address to transfer tokenID, the from address should be the owner or operator of the owner",
+		Args:                  cobra.MinimumNArgs(2),
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tokenID, err := parseTokenID(args[0])
+			if err != nil {
+				return err
+			}
+			if !common.IsHexAddress(args[1]) {
+				return validationErrorf("illegal approved address %s", args[1])
+			}
+			to := common.HexToAddress(args[1])
+			if to == (common.Address{}) {
+				return validationErrorf("the approved address is zero address, use clear to clear the approval")
+			}
+
+			return cli.changeApproval(tokenID, to)
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:                   "clear <tokenID>",
+		Short:                 "clear the approved address of tokenID",
+		Args:                  cobra.MinimumNArgs(1),
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tokenID, err := parseTokenID(args[0])
+			if err != nil {
+				return err
+			}
+
+			return cli.changeApproval(tokenID, common.Address{})
+		},
+	})
+
+	return cmd
+}
+
+func (cli *CLI) changeApproval(tokenID *big.Int, to common.Address) error {
+	if cli.mode != cli.blockchain.ModeERC721() {
+		return validationErrorf("%v", cli.blockchain.errOnlyERC721())
+	}
+	if cli.address == "" || !common.IsHexAddress(cli.address) {
+		return configErrorf("not set from address or from address illegal")
+	}
+
+	tok, err := cli.GetToken()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+	defer cancel()
+
+	approved, err := tok.GetApproved(ctx, tokenID)
+	if err != nil {
+		return tokenErrorf(CategoryRPC, "GetApproved: Get approved Error(%w)", err)
+	}
+	if approved == to {
+		cli.printf("The approved address of tokenID %s is %s already\n", tokenID.String(), to.String())
+		return cli.showApproval(ctx, tokenID, nil, nil)
+	}
+
+	opts, err := cli.getTransactOpts(cli.address)
+	if err != nil {
+		return err
+	}
+	opts.Context = ctx
+
+	if to == (common.Address{}) {
+		cli.printf("Try to clear the approved address of tokenID %s ...\n", tokenID.String())
+	} else {
+		cli.printf("Try to approve %s to transfer tokenID %s ...\n", to.String(), tokenID.String())
+	}
+	tx, err := tok.ApproveTokenID(opts, to, tokenID)
+	if err != nil {
+		return tokenErrorf(CategoryRPC, "approve error: %w", err)
+	}
+	cli.printf("Succeed submit approve of tokenID %s, TxID %s.\n", tokenID.String(), tx.Hash().String())
+
+	receipt, err := cli.waitMined(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	if err := cli.showApproval(ctx, tokenID, tx, receipt); err != nil {
+		return err
+	}
+
+	if !receipt.Succeeded() {
+		return revertedErrorf("the tx %s is confirmed but status is failed", tx.Hash().String())
+	}
+
+	return nil
+}
+
+// showApproval shows the owner and approved address of tokenID, with the
+// tx which changed it if any
+func (cli *CLI) showApproval(ctx context.Context, tokenID *big.Int, tx *types.Transaction, receipt *token.Receipt) error {
+	tok, err := cli.GetToken()
+	if err != nil {
+		return err
+	}
+
+	owner, err := tok.OwnerOf(ctx, tokenID)
+	if err != nil {
+		return tokenErrorf(CategoryRPC, "OwnerOf: OwnerOf Error(%w)", err)
+	}
+	approved, err := tok.GetApproved(ctx, tokenID)
+	if err != nil {
+		return tokenErrorf(CategoryRPC, "GetApproved: Get approved Error(%w)", err)
+	}
+
+	if approved == (common.Address{}) {
+		cli.printf("The tokenID %s of owner %s has no approved address\n", tokenID.String(), owner.String())
+	} else {
+		cli.printf("The tokenID %s of owner %s is approved to %s\n", tokenID.String(), owner.String(), approved.String())
+	}
+
+	if cli.isJSON() {
+		cli.printJSON(nftApproveJSON{
+			TokenID:  tokenID.String(),
+			Owner:    owner.String(),
+			Approved: approved.String(),
+			Tx:       newTxJSON(tx, receipt),
+		})
+	}
+
+	return nil
+}
+
+func (cli *CLI) buildNFTOperatorCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "operator [show|add|remove]",
+		Short: "Manage the operators which can transfer all the tokens of owner",
+		Args:  cobra.MinimumNArgs(1),
+		RunE:  cli.unknownCommand,
+	}
+
+	showCmd := &cobra.Command{
+		Use:                   "show <operator> [--owner ownerAddress]",
+		Short:                 "show whether the operator can transfer all the tokens of owner",
+		Args:                  cobra.MinimumNArgs(1),
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cli.mode != cli.blockchain.ModeERC721() {
+				return validationErrorf("%v", cli.blockchain.errOnlyERC721())
+			}
+			if !common.IsHexAddress(args[0]) {
+				return validationErrorf("illegal operator address %s", args[0])
+			}
+			operator := common.HexToAddress(args[0])
+
+			ownerStr := cli.address
+			if cmd.Flags().Changed("owner") {
+				ownerStr, _ = cmd.Flags().GetString("owner")
+			}
+			if !common.IsHexAddress(ownerStr) {
+				fmt.Fprint(cli.stderr, cmd.UsageString())
+				return validationErrorf("not set owner address or owner address illegal")
+			}
+
+			return cli.showOperator(context.Background(), common.HexToAddress(ownerStr), operator, nil, nil)
+		},
+	}
+	showCmd.Flags().String("owner", "", "the owner address of the tokens, default is the from address")
+	cmd.AddCommand(showCmd)
+
+	for _, approved := range []bool{true, false} {
+		approved := approved
+		use, short := "add <operator>", "allow the operator to transfer all the tokens of the from address"
+		if !approved {
+			use, short = "remove <operator>", "disallow the operator to transfer the tokens of the from address"
+		}
+		cmd.AddCommand(&cobra.Command{
+			Use:                   use,
+			Short:                 short,
+			Args:                  cobra.MinimumNArgs(1),
+			DisableFlagsInUseLine: true,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				if !common.IsHexAddress(args[0]) {
+					return validationErrorf("illegal operator address %s", args[0])
+				}
+
+				return cli.changeOperator(common.HexToAddress(args[0]), approved)
+			},
+		})
+	}
+
+	return cmd
+}
+
+func (cli *CLI) changeOperator(operator common.Address, approved bool) error {
+	if cli.mode != cli.blockchain.ModeERC721() {
+		return validationErrorf("%v", cli.blockchain.errOnlyERC721())
+	}
+	if cli.address == "" || !common.IsHexAddress(cli.address) {
+		return configErrorf("not set from address of owner or from address illegal")
+	}
+	owner := common.HexToAddress(cli.address)
+
+	tok, err := cli.GetToken()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+	defer cancel()
+
+	current, err := tok.IsApprovedForAll(ctx, owner, operator)
+	if err != nil {
+		return tokenErrorf(CategoryRPC, "IsApprovedForAll: Get operator Error(%w)", err)
+	}
+	if current == approved {
+		if approved {
+			cli.printf("%s is operator of %s already\n", operator.String(), owner.String())
+		} else {
+			cli.printf("%s is not operator of %s already\n", operator.String(), owner.String())
+		}
+		return cli.showOperator(ctx, owner, operator, nil, nil)
+	}
+
+	opts, err := cli.getTransactOpts(owner.String())
+	if err != nil {
+		return err
+	}
+	opts.Context = ctx
+
+	if approved {
+		cli.printf("Try to add operator %s of %s ...\n", operator.String(), owner.String())
+	} else {
+		cli.printf("Try to remove operator %s of %s ...\n", operator.String(), owner.String())
+	}
+	tx, err := tok.SetApprovalForAll(opts, operator, approved)
+	if err != nil {
+		return tokenErrorf(CategoryRPC, "set operator error: %w", err)
+	}
+	cli.printf("Succeed submit set operator %s, TxID %s.\n", operator.String(), tx.Hash().String())
+
+	receipt, err := cli.waitMined(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	if err := cli.showOperator(ctx, owner, operator, tx, receipt); err != nil {
+		return err
+	}
+
+	if !receipt.Succeeded() {
+		return revertedErrorf("the tx %s is confirmed but status is failed", tx.Hash().String())
+	}
+
+	return nil
+}
+
+// showOperator shows whether operator can transfer all the tokens of owner,
+// with the tx which changed it if any
+func (cli *CLI) showOperator(ctx context.Context, owner, operator common.Address, tx *types.Transaction, receipt *token.Receipt) error {
+	tok, err := cli.GetToken()
+	if err != nil {
+		return err
+	}
+
+	approved, err := tok.IsApprovedForAll(ctx, owner, operator)
+	if err != nil {
+		return tokenErrorf(CategoryRPC, "IsApprovedForAll: Get operator Error(%w)", err)
+	}
+
+	if approved {
+		cli.printf("%s is operator of %s\n", operator.String(), owner.String())
+	} else {
+		cli.printf("%s is not operator of %s\n", operator.String(), owner.String())
+	}
+
+	if cli.isJSON() {
+		cli.printJSON(nftOperatorJSON{
+			Owner:    owner.String(),
+			Operator: operator.String(),
+			Approved: approved,
+			Tx:       newTxJSON(tx, receipt),
+		})
+	}
+
+	return nil
+}
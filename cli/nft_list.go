@@ -0,0 +1,290 @@
+package cli
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/newtonproject/tokencommander/token"
+	"github.com/spf13/cobra"
+)
+
+type nftTokenJSON struct {
+	Index    uint64 `json:"index"`
+	TokenID  string `json:"tokenID"`
+	Owner    string `json:"owner"`
+	TokenURI string `json:"tokenURI"`
+}
+
+type nftListJSON struct {
+	TotalSupply string         `json:"totalSupply"`
+	Start       uint64         `json:"start"`
+	Listed      int            `json:"listed"`
+	Output      string         `json:"output,omitempty"`
+	Tokens      []nftTokenJSON `json:"tokens,omitempty"`
+}
+
+func (cli *CLI) buildNFTListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "list [--out <tokens.csv|tokens.json>] [--resume] [--start index] [--concurrency n]",
+		Short:                 "List the tokenID, owner and URI of all the tokens, and export them to csv or json file",
+		Args:                  cobra.NoArgs,
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cli.mode != cli.blockchain.ModeERC721() {
+				return validationErrorf("%v", cli.blockchain.errOnlyERC721())
+			}
+
+			output, _ := cmd.Flags().GetString("out")
+			format, _ := cmd.Flags().GetString("format")
+			if !cmd.Flags().Changed("format") && strings.EqualFold(filepath.Ext(output), ".json") {
+				format = "json"
+			}
+			if format != "csv" && format != "json" {
+				return validationErrorf("unknown export format %s, use csv or json", format)
+			}
+			resume, _ := cmd.Flags().GetBool("resume")
+			start, _ := cmd.Flags().GetUint64("start")
+			concurrency, _ := cmd.Flags().GetInt("concurrency")
+			if concurrency <= 0 {
+				return validationErrorf("the concurrency should be greater than 0")
+			}
+
+			var listed []nftTokenJSON
+			if resume {
+				if output == "" {
+					return validationErrorf("the --out file to resume not set")
+				}
+				if cmd.Flags().Changed("start") {
+					return validationErrorf("--resume continues after the last index of the --out file, conflicts with --start")
+				}
+				var err error
+				listed, err = loadNFTList(output, format)
+				if err != nil {
+					return err
+				}
+				if len(listed) > 0 {
+					start = listed[len(listed)-1].Index + 1
+				}
+				cli.printf("Resume listing from index %d after %d tokens of %s\n", start, len(listed), output)
+			}
+
+			return cli.listNFT(output, format, start, concurrency, listed)
+		},
+	}
+
+	cmd.Flags().String("out", "", "the file to export the tokens, the format is json for .json file and csv for others")
+	cmd.Flags().String("format", "csv", "the export format, csv|json")
+	cmd.Flags().Bool("resume", false, "continue the listing after the last index of the --out file")
+	cmd.Flags().Uint64("start", 0, "the index to start listing")
+	cmd.Flags().Int("concurrency", token.DefaultConcurrency, "the number of tokens fetched in parallel")
+
+	return cmd
+}
+
+// nftListSaveSize is the number of tokens listed between the saves of the
+// export file
+const nftListSaveSize = 500
+
+// listNFT lists the tokens from index start, and exports them after the
+// listed tokens to output if set. The export file is saved every
+// nftListSaveSize tokens fetched in order, and when failed or interrupted,
+// so the listing can be resumed.
+func (cli *CLI) listNFT(output, format string, start uint64, concurrency int, listed []nftTokenJSON) error {
+	tok, err := cli.GetToken()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	totalSupply, err := tok.TotalSupply(ctx)
+	if err != nil {
+		return rpcErrorf("TotalSupply: Get totalSupply Error(%v)", err)
+	}
+	total := totalSupply.Uint64()
+	if start > total {
+		return validationErrorf("the start index %d is greater than the total supply %d", start, total)
+	}
+
+	if output != "" {
+		cli.printf("Try to list the tokens from index %d to %d ...\n", start, total)
+
+		// stop on interrupt to save the tokens listed
+		interrupt := make(chan os.Signal, 1)
+		signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+		defer signal.Stop(interrupt)
+		go func() {
+			select {
+			case <-interrupt:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+	}
+
+	count := 0
+	var listErr error
+	for from := start; from < total && listErr == nil; from += nftListSaveSize {
+		to := from + nftListSaveSize
+		if to > total {
+			to = total
+		}
+		var tokens []token.CollectionToken
+		tokens, listErr = tok.ListTokens(ctx, from, to, concurrency, func(fetched int) {
+			fetched += count
+			if output != "" && (fetched%100 == 0 || uint64(fetched) == total-start) {
+				cli.printf("Fetched %d/%d tokens\n", fetched, total-start)
+			}
+		})
+		count += len(tokens)
+
+		for _, t := range tokens {
+			listed = append(listed, nftTokenJSON{
+				Index:    t.Index,
+				TokenID:  t.TokenID.String(),
+				Owner:    t.Owner.String(),
+				TokenURI: t.TokenURI,
+			})
+		}
+		if output != "" && len(tokens) > 0 {
+			if err := saveNFTList(output, format, listed); err != nil {
+				return newError(CategoryGeneral, "export to %s error: %v", output, err)
+			}
+		}
+	}
+	// export the listed tokens even if none is listed this time
+	if output != "" && count == 0 {
+		if err := saveNFTList(output, format, listed); err != nil {
+			return newError(CategoryGeneral, "export to %s error: %v", output, err)
+		}
+	}
+
+	result := nftListJSON{
+		TotalSupply: totalSupply.String(),
+		Start:       start,
+		Listed:      count,
+		Output:      output,
+	}
+	if output == "" {
+		for _, t := range listed {
+			cli.printf("%d,%s,%s,%s\n", t.Index, t.TokenID, t.Owner, t.TokenURI)
+		}
+		result.Tokens = listed
+	} else {
+		cli.printf("Exported %d tokens to %s\n", len(listed), output)
+	}
+
+	if cli.isJSON() {
+		cli.printJSON(result)
+	}
+
+	if listErr != nil {
+		next := start + uint64(count)
+		if output != "" {
+			return tokenErrorf(CategoryRPC, "list the token of index %d error(%w), run again with --resume to continue", next, listErr)
+		}
+		return tokenErrorf(CategoryRPC, "list the token of index %d error(%w), run again with --start %d to continue", next, listErr, next)
+	}
+
+	return nil
+}
+
+var nftListHeader = []string{"index", "tokenID", "owner", "uri"}
+
+// loadNFTList loads the tokens exported by saveNFTList
+func loadNFTList(path, format string) ([]nftTokenJSON, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	var tokens []nftTokenJSON
+	if format == "json" {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, validationErrorf("%v", err)
+		}
+		if err := json.Unmarshal(data, &tokens); err != nil {
+			return nil, validationErrorf("parse %s error: %v", path, err)
+		}
+	} else {
+		rows, err := readCSVRows(path, nftListHeader...)
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			index, err := strconv.ParseUint(row.Fields[0], 10, 64)
+			if err != nil {
+				return nil, validationErrorf("parse %s error: illegal index on row %d", path, row.Row)
+			}
+			tokens = append(tokens, nftTokenJSON{
+				Index:    index,
+				TokenID:  row.Fields[1],
+				Owner:    row.Fields[2],
+				TokenURI: row.Fields[3],
+			})
+		}
+	}
+
+	for i, t := range tokens {
+		if i > 0 && t.Index != tokens[i-1].Index+1 {
+			return nil, validationErrorf("the index %d of %s is not after %d, can not resume", t.Index, path, tokens[i-1].Index)
+		}
+		if _, ok := big.NewInt(0).SetString(t.TokenID, 10); !ok || !common.IsHexAddress(t.Owner) {
+			return nil, validationErrorf("illegal tokenID %s or owner %s of index %d in %s", t.TokenID, t.Owner, t.Index, path)
+		}
+	}
+
+	return tokens, nil
+}
+
+// saveNFTList exports the tokens to path by a temporary file, so the
+// exported file is not broken if interrupted
+func saveNFTList(path, format string, tokens []nftTokenJSON) error {
+	tmp := path + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+	defer file.Close()
+
+	if format == "json" {
+		if tokens == nil {
+			tokens = []nftTokenJSON{}
+		}
+		encoder := json.NewEncoder(file)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(tokens); err != nil {
+			return err
+		}
+	} else {
+		w := csv.NewWriter(file)
+		if err := w.Write(nftListHeader); err != nil {
+			return err
+		}
+		for _, t := range tokens {
+			if err := w.Write([]string{strconv.FormatUint(t.Index, 10), t.TokenID, t.Owner, t.TokenURI}); err != nil {
+				return err
+			}
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			return err
+		}
+	}
+
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,176 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/newtonproject/tokencommander/token"
+	"github.com/spf13/cobra"
+)
+
+type metadataCheckJSON struct {
+	TokenID     string   `json:"tokenID"`
+	TokenURI    string   `json:"tokenURI"`
+	ResolvedURI string   `json:"resolvedURI,omitempty"`
+	Error       string   `json:"error,omitempty"`
+	Problems    []string `json:"problems,omitempty"`
+}
+
+type metadataCheckListJSON struct {
+	Checked int                 `json:"checked"`
+	Failed  int                 `json:"failed"`
+	Tokens  []metadataCheckJSON `json:"tokens"`
+}
+
+func newMetadataCheckJSON(check *token.MetadataCheck) metadataCheckJSON {
+	result := metadataCheckJSON{
+		TokenID:     check.TokenID.String(),
+		TokenURI:    check.TokenURI,
+		ResolvedURI: check.ResolvedURI,
+	}
+	if check.Err != nil {
+		result.Error = check.Err.Error()
+	}
+	for _, p := range check.Problems {
+		result.Problems = append(result.Problems, p.String())
+	}
+	return result
+}
+
+// addMetadataFlags adds the flags of the metadata resolver to cmd
+func addMetadataFlags(cmd *cobra.Command) {
+	cmd.Flags().String("ipfs-gateway", "", fmt.Sprintf("the http gateway of ipfs:// URIs, default is ipfsGateway of config or %s", token.DefaultIPFSGateway))
+	cmd.Flags().String("base-uri", "", "the base URI of the relative token URIs, default is baseURI of config")
+}
+
+// metadataResolver returns the metadata resolver of the flags of
+// addMetadataFlags, or of ipfsGateway and baseURI of the config
+func (cli *CLI) metadataResolver(cmd *cobra.Command) *token.MetadataResolver {
+	r := &token.MetadataResolver{
+		IPFSGateway: cli.v.GetString("ipfsGateway"),
+		BaseURI:     cli.v.GetString("baseURI"),
+	}
+	if cmd.Flags().Changed("ipfs-gateway") {
+		r.IPFSGateway, _ = cmd.Flags().GetString("ipfs-gateway")
+	}
+	if cmd.Flags().Changed("base-uri") {
+		r.BaseURI, _ = cmd.Flags().GetString("base-uri")
+	}
+	return r
+}
+
+func (cli *CLI) buildNFTMetadataCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "metadata [check]",
+		Short: "Audit the metadata of the tokens",
+		Args:  cobra.MinimumNArgs(1),
+		RunE:  cli.unknownCommand,
+	}
+
+	checkCmd := &cobra.Command{
+		Use:                   "check [tokenID...] [--concurrency n] [--ipfs-gateway url] [--base-uri uri]",
+		Short:                 "Check the metadata of the tokenIDs, or of all the tokens, against the ERC721 metadata JSON schema",
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cli.mode != cli.blockchain.ModeERC721() {
+				return validationErrorf("%v", cli.blockchain.errOnlyERC721())
+			}
+			concurrency, _ := cmd.Flags().GetInt("concurrency")
+			if concurrency <= 0 {
+				return validationErrorf("the concurrency should be greater than 0")
+			}
+
+			tokenIDs := make([]*big.Int, 0, len(args))
+			for _, arg := range args {
+				tokenID, err := parseTokenID(arg)
+				if err != nil {
+					return err
+				}
+				tokenIDs = append(tokenIDs, tokenID)
+			}
+
+			return cli.checkMetadata(cli.metadataResolver(cmd), tokenIDs, concurrency)
+		},
+	}
+	addMetadataFlags(checkCmd)
+	checkCmd.Flags().Int("concurrency", token.DefaultConcurrency, "the number of tokens fetched in parallel")
+	cmd.AddCommand(checkCmd)
+
+	return cmd
+}
+
+// checkMetadata checks the metadata of tokenIDs, or of all the tokens if
+// no tokenID, and reports the problems of each token
+func (cli *CLI) checkMetadata(resolver *token.MetadataResolver, tokenIDs []*big.Int, concurrency int) error {
+	tok, err := cli.GetToken()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	var tokens []token.CollectionToken
+	if len(tokenIDs) == 0 {
+		totalSupply, err := tok.TotalSupply(ctx)
+		if err != nil {
+			return rpcErrorf("TotalSupply: Get totalSupply Error(%v)", err)
+		}
+		cli.printf("Try to list the URIs of %s tokens ...\n", totalSupply.String())
+		tokens, err = tok.ListTokens(ctx, 0, totalSupply.Uint64(), concurrency, nil)
+		if err != nil {
+			return tokenErrorf(CategoryRPC, "list the token of index %d error(%w)", len(tokens), err)
+		}
+	} else {
+		for _, tokenID := range tokenIDs {
+			tokenURI, err := tok.TokenURI(ctx, tokenID)
+			if err != nil {
+				return tokenErrorf(CategoryRPC, "TokenURI: Get token uri of tokenID %s error(%w)", tokenID.String(), err)
+			}
+			tokens = append(tokens, token.CollectionToken{TokenID: tokenID, TokenURI: tokenURI})
+		}
+	}
+
+	cli.printf("Try to check the metadata of %d tokens ...\n", len(tokens))
+	checks := resolver.CheckAll(ctx, tokens, concurrency, func(checked int) {
+		if checked%100 == 0 {
+			cli.printf("Checked %d/%d tokens\n", checked, len(tokens))
+		}
+	})
+
+	result := metadataCheckListJSON{Checked: len(checks), Tokens: make([]metadataCheckJSON, 0, len(checks))}
+	for _, check := range checks {
+		if check.OK() {
+			continue
+		}
+		result.Failed++
+		result.Tokens = append(result.Tokens, newMetadataCheckJSON(check))
+		if check.Err != nil {
+			cli.printf("TokenID %s(%s): %v\n", check.TokenID.String(), check.TokenURI, check.Err)
+			continue
+		}
+		for _, p := range check.Problems {
+			cli.printf("TokenID %s(%s): %s\n", check.TokenID.String(), check.TokenURI, p)
+		}
+	}
+	cli.printf("Checked the metadata of %d tokens, %d with problems\n", result.Checked, result.Failed)
+
+	if cli.isJSON() {
+		cli.printJSON(result)
+	}
+
+	if result.Failed > 0 {
+		return validationErrorf("the metadata of %d of %d tokens has problems", result.Failed, result.Checked)
+	}
+
+	return nil
+}
+
+// metadataRaw returns the metadata JSON of the check to show, or nil if it
+// is not valid JSON
+func metadataRaw(check *token.MetadataCheck) json.RawMessage {
+	if check.Err != nil || !json.Valid(check.Metadata) {
+		return nil
+	}
+	return json.RawMessage(check.Metadata)
+}
@@ -0,0 +1,214 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/newtonproject/tokencommander/contracts/ERC721"
+	"github.com/newtonproject/tokencommander/token"
+)
+
+func TestNFTApprove(t *testing.T) {
+	cli := NewCLI()
+
+	cli.TestCommand("nft approve show 1 --mode NRC7")
+	cli.TestCommand("nft approve set 1 0xDC8F76075Db000Fa70fdA3AA2c95d63F22A10a67 --mode NRC7")
+	cli.TestCommand("nft approve clear 1 --mode NRC7")
+}
+
+func TestNFTOperator(t *testing.T) {
+	cli := NewCLI()
+
+	cli.TestCommand("nft operator show 0xDC8F76075Db000Fa70fdA3AA2c95d63F22A10a67 --mode NRC7")
+	cli.TestCommand("nft operator add 0xDC8F76075Db000Fa70fdA3AA2c95d63F22A10a67 --mode NRC7")
+	cli.TestCommand("nft operator remove 0xDC8F76075Db000Fa70fdA3AA2c95d63F22A10a67 --mode NRC7")
+}
+
+func TestNFTURI(t *testing.T) {
+	cli := NewCLI()
+
+	cli.TestCommand("nft uri show 1 --mode NRC7")
+	cli.TestCommand("nft uri set-base https://example.com/tokens/ --mode NRC7")
+	cli.TestCommand("nft uri set 1 https://example.com/tokens/1.json --mode NRC7")
+	cli.TestCommand("nft uri set --csv uris.csv --mode NRC7")
+}
+
+func TestReadCSVRows(t *testing.T) {
+	dir, err := ioutil.TempDir("", "csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "uris.csv")
+	content := "TokenID, URI\n# comment\n1, ipfs://a\n\n2,ipfs://b\n"
+	if err := ioutil.WriteFile(file, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := readCSVRows(file, "tokenID", "uri")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []csvRow{
+		{Row: 2, Fields: []string{"1", "ipfs://a"}},
+		{Row: 3, Fields: []string{"2", "ipfs://b"}},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("readCSVRows: want %v, got %v", want, rows)
+	}
+
+	if err := ioutil.WriteFile(file, []byte("1,ipfs://a,extra\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readCSVRows(file, "tokenID", "uri"); err == nil {
+		t.Error("readCSVRows: want error of the wrong number of fields")
+	}
+
+	if err := ioutil.WriteFile(file, []byte("address\n0x01\n0x02,ipfs://b\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	rows, err = readCSVRows(file, "address", "[uri]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = []csvRow{
+		{Row: 2, Fields: []string{"0x01", ""}},
+		{Row: 3, Fields: []string{"0x02", "ipfs://b"}},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("readCSVRows with optional column: want %v, got %v", want, rows)
+	}
+}
+
+func TestNFTList(t *testing.T) {
+	cli := NewCLI()
+
+	cli.TestCommand("nft list --mode NRC7")
+	cli.TestCommand("nft list --out tokens.csv --concurrency 4 --mode NRC7")
+	cli.TestCommand("nft list --out tokens.json --resume --mode NRC7")
+}
+
+// fakeCollectionBackend answers the calls of a collection of total tokens,
+// and fails the tokenByIndex of failIndex
+type fakeCollectionBackend struct {
+	token.Backend
+	abi       abi.ABI
+	total     int64
+	failIndex int64
+}
+
+func (b *fakeCollectionBackend) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	method, err := b.abi.MethodById(call.Data[:4])
+	if err != nil {
+		return nil, err
+	}
+	args, err := method.Inputs.Unpack(call.Data[4:])
+	if err != nil {
+		return nil, err
+	}
+	switch method.Name {
+	case "totalSupply":
+		return method.Outputs.Pack(big.NewInt(b.total))
+	case "tokenByIndex":
+		index := args[0].(*big.Int)
+		if index.Int64() == b.failIndex {
+			return nil, errors.New("connection reset")
+		}
+		return method.Outputs.Pack(big.NewInt(0).Add(index, big.NewInt(1000)))
+	case "ownerOf":
+		return method.Outputs.Pack(common.HexToAddress("0xDC8F76075Db000Fa70fdA3AA2c95d63F22A10a67"))
+	case "tokenURI":
+		return method.Outputs.Pack(fmt.Sprintf("ipfs://token/%s", args[0].(*big.Int).String()))
+	}
+	return nil, fmt.Errorf("unexpected call of %s", method.Name)
+}
+
+func TestListNFTSavePartial(t *testing.T) {
+	dir, err := ioutil.TempDir("", "nftlist")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	parsed, err := abi.JSON(strings.NewReader(ERC721.NRC7FullABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend := &fakeCollectionBackend{abi: parsed, total: 1200, failIndex: 700}
+	cli := NewCLI().SetOutput(ioutil.Discard, ioutil.Discard)
+	cli.token, err = token.New(common.Address{}, token.NonFungible, backend)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	output := filepath.Join(dir, "tokens.csv")
+	if err := cli.listNFT(output, "csv", 0, 4, nil); err == nil {
+		t.Fatal("listNFT: want the error of index 700")
+	}
+	listed, err := loadNFTList(output, "csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(listed) != 700 || listed[699].Index != 699 {
+		t.Fatalf("listNFT: want the tokens before index 700 exported, got %d tokens", len(listed))
+	}
+
+	backend.failIndex = -1
+	if err := cli.listNFT(output, "csv", 700, 4, listed); err != nil {
+		t.Fatal(err)
+	}
+	if listed, err = loadNFTList(output, "csv"); err != nil || len(listed) != 1200 {
+		t.Errorf("listNFT resumed: want 1200 tokens exported, got %d, %v", len(listed), err)
+	}
+}
+
+func TestSaveNFTList(t *testing.T) {
+	dir, err := ioutil.TempDir("", "nftlist")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tokens := []nftTokenJSON{
+		{Index: 3, TokenID: "10", Owner: "0xDC8F76075Db000Fa70fdA3AA2c95d63F22A10a67", TokenURI: "ipfs://a,b"},
+		{Index: 4, TokenID: "12", Owner: "0xDC8F76075Db000Fa70fdA3AA2c95d63F22A10a67"},
+	}
+	for _, format := range []string{"csv", "json"} {
+		file := filepath.Join(dir, "tokens."+format)
+		if err := saveNFTList(file, format, tokens); err != nil {
+			t.Fatalf("saveNFTList %s: %v", format, err)
+		}
+		got, err := loadNFTList(file, format)
+		if err != nil {
+			t.Fatalf("loadNFTList %s: %v", format, err)
+		}
+		if !reflect.DeepEqual(got, tokens) {
+			t.Errorf("loadNFTList %s: want %v, got %v", format, tokens, got)
+		}
+	}
+
+	got, err := loadNFTList(filepath.Join(dir, "missing.csv"), "csv")
+	if err != nil || len(got) != 0 {
+		t.Errorf("loadNFTList of missing file: got %v, error %v", got, err)
+	}
+}
+
+func TestNFTMetadata(t *testing.T) {
+	cli := NewCLI()
+
+	cli.TestCommand("nft metadata check --mode NRC7")
+	cli.TestCommand("nft metadata check 1 2 --ipfs-gateway https://gateway.example.com/ipfs/ --base-uri https://example.com/tokens/ --mode NRC7")
+	cli.TestCommand("info 1 --metadata --ipfs-gateway https://gateway.example.com/ipfs/ --mode NRC7")
+}
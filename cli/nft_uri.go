@@ -0,0 +1,297 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+type nftURIJSON struct {
+	TokenID  string  `json:"tokenID"`
+	TokenURI string  `json:"tokenURI"`
+	Tx       *txJSON `json:"tx,omitempty"`
+	Error    string  `json:"error,omitempty"`
+}
+
+type nftBaseURIJSON struct {
+	BaseURI string  `json:"baseURI"`
+	Tx      *txJSON `json:"tx"`
+}
+
+type nftURIBulkJSON struct {
+	Total  int          `json:"total"`
+	Failed int          `json:"failed"`
+	Rows   []nftURIJSON `json:"rows"`
+}
+
+func (cli *CLI) buildNFTURICmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "uri [show|set|set-base]",
+		Short: "Manage the URIs of the tokens",
+		Args:  cobra.MinimumNArgs(1),
+		RunE:  cli.unknownCommand,
+	}
+
+	cmd.AddCommand(cli.buildNFTURIShowCmd())
+	cmd.AddCommand(cli.buildNFTURISetCmd())
+	cmd.AddCommand(cli.buildNFTURISetBaseCmd())
+
+	return cmd
+}
+
+func (cli *CLI) buildNFTURIShowCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "show <tokenID>",
+		Short:                 "show the URI of tokenID",
+		Args:                  cobra.MinimumNArgs(1),
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cli.mode != cli.blockchain.ModeERC721() {
+				return validationErrorf("%v", cli.blockchain.errOnlyERC721())
+			}
+			tokenID, err := parseTokenID(args[0])
+			if err != nil {
+				return err
+			}
+
+			tok, err := cli.GetToken()
+			if err != nil {
+				return err
+			}
+			tokenURI, err := tok.TokenURI(context.Background(), tokenID)
+			if err != nil {
+				return tokenErrorf(CategoryRPC, "TokenURI: Get token uri error(%w)", err)
+			}
+			cli.printf("The URI of tokenID %s is %s\n", tokenID.String(), tokenURI)
+
+			if cli.isJSON() {
+				cli.printJSON(nftURIJSON{TokenID: tokenID.String(), TokenURI: tokenURI})
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func (cli *CLI) buildNFTURISetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "set <tokenID> <uri> | set --csv <uris.csv>",
+		Short:                 "set the URI of tokenID, or of each tokenID,uri row of the csv file, the from address should have MINTER_ROLE",
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cli.mode != cli.blockchain.ModeERC721() {
+				return validationErrorf("%v", cli.blockchain.errOnlyERC721())
+			}
+			if cli.address == "" || !common.IsHexAddress(cli.address) {
+				return configErrorf("not set from address of minter or from address illegal")
+			}
+
+			if cmd.Flags().Changed("csv") {
+				path, _ := cmd.Flags().GetString("csv")
+				return cli.setTokenURIs(path)
+			}
+
+			if len(args) < 2 {
+				fmt.Fprint(cli.stderr, cmd.UsageString())
+				return validationErrorf("the tokenID and uri not set")
+			}
+			tokenID, err := parseTokenID(args[0])
+			if err != nil {
+				return err
+			}
+
+			return cli.setTokenURI(tokenID, args[1])
+		},
+	}
+
+	cmd.Flags().String("csv", "", "the csv file of tokenID,uri rows to set in bulk")
+
+	return cmd
+}
+
+func (cli *CLI) setTokenURI(tokenID *big.Int, tokenURI string) error {
+	tok, err := cli.GetToken()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+	defer cancel()
+
+	opts, err := cli.getTransactOpts(cli.address)
+	if err != nil {
+		return err
+	}
+	opts.Context = ctx
+
+	cli.printf("Try to set the URI of tokenID %s to %s ...\n", tokenID.String(), tokenURI)
+	tx, err := tok.SetTokenURI(opts, tokenID, tokenURI)
+	if err != nil {
+		return tokenErrorf(CategoryRPC, "set token uri error(%w)", err)
+	}
+	cli.printf("Succeed submit set token uri, TxID %s.\n", tx.Hash().String())
+
+	receipt, err := cli.waitMined(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	if cli.isJSON() {
+		cli.printJSON(nftURIJSON{
+			TokenID:  tokenID.String(),
+			TokenURI: tokenURI,
+			Tx:       newTxJSON(tx, receipt),
+		})
+	}
+
+	if !receipt.Succeeded() {
+		return revertedErrorf("the tx %s is confirmed but status is failed", tx.Hash().String())
+	}
+
+	return nil
+}
+
+// setTokenURIs sets the URIs of the tokenID,uri rows of the csv file one by
+// one, and reports the progress of each row. The failed rows are reported
+// and skipped.
+func (cli *CLI) setTokenURIs(path string) error {
+	rows, err := readCSVRows(path, "tokenID", "uri")
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return validationErrorf("no tokenID,uri row in %s", path)
+	}
+
+	tokenIDs := make([]*big.Int, 0, len(rows))
+	for _, row := range rows {
+		tokenID, err := parseTokenID(row.Fields[0])
+		if err != nil {
+			return validationErrorf("row %d: %v", row.Row, err)
+		}
+		tokenIDs = append(tokenIDs, tokenID)
+	}
+
+	tok, err := cli.GetToken()
+	if err != nil {
+		return err
+	}
+
+	opts, err := cli.getTransactOpts(cli.address)
+	if err != nil {
+		return err
+	}
+
+	result := nftURIBulkJSON{Total: len(rows), Rows: make([]nftURIJSON, 0, len(rows))}
+	var firstErr error
+	for i, row := range rows {
+		tokenID, tokenURI := tokenIDs[i], row.Fields[1]
+		r := nftURIJSON{TokenID: tokenID.String(), TokenURI: tokenURI}
+
+		err := func() error {
+			ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+			defer cancel()
+			opts.Context = ctx
+
+			tx, err := tok.SetTokenURI(opts, tokenID, tokenURI)
+			if err != nil {
+				return tokenErrorf(CategoryRPC, "set token uri error(%w)", err)
+			}
+			cli.printf("[%d/%d] Succeed submit set the URI of tokenID %s, TxID %s.\n", i+1, len(rows), tokenID.String(), tx.Hash().String())
+
+			receipt, err := cli.waitMined(ctx, tx)
+			if err != nil {
+				return err
+			}
+			r.Tx = newTxJSON(tx, receipt)
+			if !receipt.Succeeded() {
+				return revertedErrorf("the tx %s is confirmed but status is failed", tx.Hash().String())
+			}
+			return nil
+		}()
+		if err != nil {
+			cli.printf("[%d/%d] Failed to set the URI of tokenID %s: %v\n", i+1, len(rows), tokenID.String(), err)
+			r.Error = err.Error()
+			result.Failed++
+			if firstErr == nil {
+				firstErr = err
+			}
+		} else {
+			cli.printf("[%d/%d] The URI of tokenID %s is %s\n", i+1, len(rows), tokenID.String(), tokenURI)
+		}
+		result.Rows = append(result.Rows, r)
+	}
+	cli.printf("Set the URIs of %d tokenIDs, %d failed\n", result.Total-result.Failed, result.Failed)
+
+	if cli.isJSON() {
+		cli.printJSON(result)
+	}
+
+	if firstErr != nil {
+		return newError(ErrorCategoryOf(firstErr), "%d of %d rows failed, the first error: %v", result.Failed, result.Total, firstErr)
+	}
+
+	return nil
+}
+
+func (cli *CLI) buildNFTURISetBaseCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "set-base <baseURI>",
+		Short:                 "set the base URI of the tokens, the from address should have DEFAULT_ADMIN_ROLE",
+		Args:                  cobra.MinimumNArgs(1),
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cli.mode != cli.blockchain.ModeERC721() {
+				return validationErrorf("%v", cli.blockchain.errOnlyERC721())
+			}
+			if cli.address == "" || !common.IsHexAddress(cli.address) {
+				return configErrorf("not set from address of admin or from address illegal")
+			}
+			baseURI := args[0]
+
+			tok, err := cli.GetToken()
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+			defer cancel()
+
+			opts, err := cli.getTransactOpts(cli.address)
+			if err != nil {
+				return err
+			}
+			opts.Context = ctx
+
+			cli.printf("Try to set the base URI to %s ...\n", baseURI)
+			tx, err := tok.SetBaseURI(opts, baseURI)
+			if err != nil {
+				return tokenErrorf(CategoryRPC, "set base uri error(%w)", err)
+			}
+			cli.printf("Succeed submit set base uri, TxID %s.\n", tx.Hash().String())
+
+			receipt, err := cli.waitMined(ctx, tx)
+			if err != nil {
+				return err
+			}
+
+			if cli.isJSON() {
+				cli.printJSON(nftBaseURIJSON{BaseURI: baseURI, Tx: newTxJSON(tx, receipt)})
+			}
+
+			if !receipt.Succeeded() {
+				return revertedErrorf("the tx %s is confirmed but status is failed", tx.Hash().String())
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
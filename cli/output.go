@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/newtonproject/tokencommander/token"
+)
+
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+var outputList = []string{outputText, outputJSON}
+
+func (cli *CLI) isJSON() bool {
+	return cli.output == outputJSON
+}
+
+// logWriter returns where the text messages go, which is stderr in json
+// output so that the json document on stdout stays parsable
+func (cli *CLI) logWriter() io.Writer {
+	if cli.isJSON() {
+		return cli.stderr
+	}
+	return cli.stdout
+}
+
+func (cli *CLI) printf(format string, a ...interface{}) {
+	fmt.Fprintf(cli.logWriter(), format, a...)
+}
+
+func (cli *CLI) println(a ...interface{}) {
+	fmt.Fprintln(cli.logWriter(), a...)
+}
+
+// printJSON prints v as the json document of the command
+func (cli *CLI) printJSON(v interface{}) {
+	b, err := json.MarshalIndent(v, "", "    ")
+	if err != nil {
+		fmt.Fprintln(cli.stderr, "JSON marshaling failed: ", err)
+		return
+	}
+	fmt.Fprintln(cli.stdout, string(b))
+	cli.jsonPrinted = true
+}
+
+type errorDetailJSON struct {
+	Category string `json:"category"`
+	Message  string `json:"message"`
+}
+
+type errorJSON struct {
+	Error errorDetailJSON `json:"error"`
+}
+
+// amountJSON is an amount in base units with the decimals to format it
+type amountJSON struct {
+	Value    string `json:"value"`
+	Decimals uint8  `json:"decimals"`
+	Text     string `json:"text"`
+	Symbol   string `json:"symbol,omitempty"`
+}
+
+func newAmountJSON(amount *big.Int, decimals uint8, symbol string) *amountJSON {
+	if amount == nil {
+		amount = big.NewInt(0)
+	}
+	return &amountJSON{
+		Value:    amount.String(),
+		Decimals: decimals,
+		Text:     token.FormatAmount(amount, decimals),
+		Symbol:   symbol,
+	}
+}
+
+const (
+	txStatusPending = "pending"
+	txStatusSuccess = "success"
+	txStatusFailed  = "failed"
+)
+
+// txJSON is a submitted transaction, with the receipt fields once mined.
+// Gas prices and fees are in WEI.
+type txJSON struct {
+	Hash        string `json:"hash"`
+	Nonce       uint64 `json:"nonce"`
+	Status      string `json:"status"`
+	GasLimit    uint64 `json:"gasLimit"`
+	GasPrice    string `json:"gasPrice"`
+	GasUsed     uint64 `json:"gasUsed,omitempty"`
+	GasFee      string `json:"gasFee,omitempty"`
+	BlockNumber string `json:"blockNumber,omitempty"`
+}
+
+func newTxJSON(tx *types.Transaction, receipt *token.Receipt) *txJSON {
+	if tx == nil {
+		return nil
+	}
+	t := &txJSON{
+		Hash:     tx.Hash().String(),
+		Nonce:    tx.Nonce(),
+		Status:   txStatusPending,
+		GasLimit: tx.Gas(),
+		GasPrice: tx.GasPrice().String(),
+	}
+	if receipt != nil {
+		t.Status = txStatusSuccess
+		if !receipt.Succeeded() {
+			t.Status = txStatusFailed
+		}
+		t.GasUsed = receipt.GasUsed
+		t.GasFee = receipt.GasFee.String()
+		if receipt.BlockNumber != nil {
+			t.BlockNumber = receipt.BlockNumber.String()
+		}
+	}
+	return t
+}
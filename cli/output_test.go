@@ -0,0 +1,25 @@
+package cli
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+)
+
+func TestAmountJSON(t *testing.T) {
+	b, err := json.Marshal(newAmountJSON(big.NewInt(1001), 2, "MT"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"value":"1001","decimals":2,"text":"10.01","symbol":"MT"}`
+	if string(b) != want {
+		t.Errorf("wrong amount json: want %s, got %s", want, string(b))
+	}
+}
+
+func TestAccountListJSON(t *testing.T) {
+	cli := NewCLI()
+
+	cli.TestCommand("account list --output json")
+}
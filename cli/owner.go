@@ -0,0 +1,201 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/newtonproject/tokencommander/token"
+	"github.com/spf13/cobra"
+)
+
+type ownerJSON struct {
+	Owner         string  `json:"owner"`
+	PreviousOwner string  `json:"previousOwner,omitempty"`
+	Tx            *txJSON `json:"tx,omitempty"`
+}
+
+func (cli *CLI) buildOwnerCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "owner [show|transfer|renounce]",
+		Short: fmt.Sprintf("Manage the owner of the contract, only for %s", cli.blockchain.ModeERC20()),
+		Args:  cobra.MinimumNArgs(1),
+		RunE:  cli.unknownCommand,
+	}
+
+	cmd.AddCommand(cli.buildOwnerShowCmd())
+	cmd.AddCommand(cli.buildOwnerTransferCmd())
+	cmd.AddCommand(cli.buildOwnerRenounceCmd())
+
+	return cmd
+}
+
+func (cli *CLI) buildOwnerShowCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "show",
+		Short:                 "show the owner of the contract",
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cli.mode != cli.blockchain.ModeERC20() {
+				return validationErrorf("%v", cli.blockchain.errOnlyERC20())
+			}
+			tok, err := cli.GetToken()
+			if err != nil {
+				return err
+			}
+
+			owner, err := tok.Owner(context.Background())
+			if err != nil {
+				return rpcErrorf("Owner: Get owner Error(%v)", err)
+			}
+			if owner == (common.Address{}) {
+				cli.println("The contract has no owner")
+			} else {
+				cli.println("The owner of the contract is", owner.String())
+			}
+
+			if cli.isJSON() {
+				cli.printJSON(ownerJSON{Owner: owner.String()})
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func (cli *CLI) buildOwnerTransferCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "transfer <newOwner> [--confirm newOwner] [--yes]",
+		Short:                 "transfer the ownership of the contract to new owner",
+		Args:                  cobra.MinimumNArgs(1),
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !common.IsHexAddress(args[0]) {
+				return validationErrorf("illegal new owner address %s", args[0])
+			}
+			newOwner := common.HexToAddress(args[0])
+			if newOwner == (common.Address{}) {
+				return validationErrorf("the new owner is zero address, use renounce to leave the contract without owner")
+			}
+
+			return cli.changeOwner(cmd, &newOwner)
+		},
+	}
+
+	cmd.Flags().String("confirm", "", "the new owner address to confirm without prompt if the new owner is a fresh account")
+	cmd.Flags().Bool("yes", false, "transfer to a fresh account without confirmation")
+
+	return cmd
+}
+
+func (cli *CLI) buildOwnerRenounceCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "renounce [--confirm symbol]",
+		Short:                 "leave the contract without owner, this can not be undone",
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cli.changeOwner(cmd, nil)
+		},
+	}
+
+	cmd.Flags().String("confirm", "", "the token symbol to confirm without prompt")
+
+	return cmd
+}
+
+// changeOwner transfers the ownership to newOwner, or renounces the
+// ownership if newOwner is nil
+func (cli *CLI) changeOwner(cmd *cobra.Command, newOwner *common.Address) error {
+	if cli.mode != cli.blockchain.ModeERC20() {
+		return validationErrorf("%v", cli.blockchain.errOnlyERC20())
+	}
+	if cli.address == "" || !common.IsHexAddress(cli.address) {
+		return configErrorf("not set from address of owner or from address illegal")
+	}
+	from := common.HexToAddress(cli.address)
+
+	tok, err := cli.GetToken()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+	defer cancel()
+
+	owner, err := tok.Owner(ctx)
+	if err != nil {
+		return rpcErrorf("Owner: Get owner Error(%v)", err)
+	}
+	if owner != from {
+		return tokenErrorf(CategoryValidation, "%w: the owner is %s not %s", token.ErrNotOwner, owner.String(), from.String())
+	}
+
+	if newOwner != nil {
+		fresh, err := token.IsFreshAccount(ctx, cli.client, *newOwner)
+		if err != nil {
+			return rpcErrorf("Check new owner error: %v", err)
+		}
+		if yes, _ := cmd.Flags().GetBool("yes"); fresh && !yes {
+			cli.printf("The new owner %s has no code and no nonce, please make sure the address is right.\n", newOwner.Hex())
+			if err := cli.confirmTyped(cmd, fmt.Sprintf("Transfer ownership to %s", newOwner.Hex()), newOwner.Hex()); err != nil {
+				return err
+			}
+		}
+	} else {
+		symbol, err := tok.Symbol(ctx)
+		if err != nil {
+			return rpcErrorf("Symbol: Get Symbol Error(%v)", err)
+		}
+		if err := cli.confirmTyped(cmd, fmt.Sprintf("Renounce ownership of %s", symbol), symbol); err != nil {
+			return err
+		}
+	}
+
+	opts, err := cli.getTransactOpts(from.String())
+	if err != nil {
+		return err
+	}
+	opts.Context = ctx
+
+	var tx *types.Transaction
+	if newOwner != nil {
+		cli.printf("Try to transfer ownership from %s to %s ...\n", owner.String(), newOwner.String())
+		tx, err = tok.TransferOwnership(opts, *newOwner)
+	} else {
+		cli.printf("Try to renounce ownership of %s ...\n", owner.String())
+		tx, err = tok.RenounceOwnership(opts)
+	}
+	if err != nil {
+		return tokenErrorf(CategoryRPC, "change owner error: %w", err)
+	}
+	cli.printf("Succeed submit change owner, TxID %s.\n", tx.Hash().String())
+
+	receipt, err := cli.waitMined(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	current, err := tok.Owner(ctx)
+	if err != nil {
+		return rpcErrorf("Owner: Get owner Error(%v)", err)
+	}
+	cli.println("The owner of the contract is", current.String())
+
+	if cli.isJSON() {
+		cli.printJSON(ownerJSON{
+			Owner:         current.String(),
+			PreviousOwner: owner.String(),
+			Tx:            newTxJSON(tx, receipt),
+		})
+	}
+
+	if !receipt.Succeeded() {
+		return revertedErrorf("the tx %s is confirmed but status is failed", tx.Hash().String())
+	}
+
+	return nil
+}
@@ -0,0 +1,11 @@
+package cli
+
+import "testing"
+
+func TestOwner(t *testing.T) {
+	cli := NewCLI()
+
+	cli.TestCommand("owner show")
+	cli.TestCommand("owner transfer 0xeF0b04a14e62434a99C4aF28C6dAb52ba9B1C8F3")
+	cli.TestCommand("owner renounce --confirm MT")
+}
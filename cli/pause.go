@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/spf13/cobra"
+)
+
+type pauseJSON struct {
+	Paused bool    `json:"paused"`
+	Tx     *txJSON `json:"tx,omitempty"`
+}
+
+func (cli *CLI) buildPauseCmd(pause bool) *cobra.Command {
+	use, short := "pause", fmt.Sprintf("Pause the transfer, mint and burn of the tokens, only for %s", cli.blockchain.ModeERC721())
+	if !pause {
+		use, short = "unpause", fmt.Sprintf("Unpause the tokens, only for %s", cli.blockchain.ModeERC721())
+	}
+
+	cmd := &cobra.Command{
+		Use:                   use,
+		Short:                 short,
+		Args:                  cobra.NoArgs,
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cli.mode != cli.blockchain.ModeERC721() {
+				return validationErrorf("%v", cli.blockchain.errOnlyERC721())
+			}
+			if cli.address == "" || !common.IsHexAddress(cli.address) {
+				return configErrorf("not set from address of pauser or from address illegal")
+			}
+
+			tok, err := cli.GetToken()
+			if err != nil {
+				return err
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+			defer cancel()
+
+			paused, err := tok.Paused(ctx)
+			if err != nil {
+				return rpcErrorf("Paused: Get paused Error(%v)", err)
+			}
+			if paused == pause {
+				if paused {
+					cli.println("The token is paused already")
+				} else {
+					cli.println("The token is not paused already")
+				}
+				if cli.isJSON() {
+					cli.printJSON(pauseJSON{Paused: paused})
+				}
+				return nil
+			}
+
+			opts, err := cli.getTransactOpts(cli.address)
+			if err != nil {
+				return err
+			}
+			opts.Context = ctx
+
+			cli.printf("Try to %s the token ...\n", use)
+			var tx *types.Transaction
+			if pause {
+				tx, err = tok.Pause(opts)
+			} else {
+				tx, err = tok.Unpause(opts)
+			}
+			if err != nil {
+				return tokenErrorf(CategoryRPC, "%s error(%w)", use, err)
+			}
+			cli.printf("Succeed submit %s, TxID %s.\n", use, tx.Hash().String())
+
+			receipt, err := cli.waitMined(ctx, tx)
+			if err != nil {
+				return err
+			}
+
+			paused, err = tok.Paused(ctx)
+			if err != nil {
+				return rpcErrorf("Paused: Get paused Error(%v)", err)
+			}
+			if paused {
+				cli.println("The token is paused")
+			} else {
+				cli.println("The token is not paused")
+			}
+
+			if cli.isJSON() {
+				cli.printJSON(pauseJSON{Paused: paused, Tx: newTxJSON(tx, receipt)})
+			}
+
+			if !receipt.Succeeded() {
+				return revertedErrorf("the tx %s is confirmed but status is failed", tx.Hash().String())
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,10 @@
+package cli
+
+import "testing"
+
+func TestPause(t *testing.T) {
+	cli := NewCLI()
+
+	cli.TestCommand("pause --mode NRC7")
+	cli.TestCommand("unpause --mode NRC7")
+}
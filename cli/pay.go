@@ -2,53 +2,89 @@ package cli
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 func (cli *CLI) buildPayCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "pay <amount|tokenID|all> <--to toAddress> [--from fromAddress]",
+		Use:     "pay <amount|tokenID|all> <--to toAddress> [--from fromAddress] [--owner ownerAddress] [--call] [--call-data <hex|@file>] [--data <hex|@file>] [--unsafe]",
 		Aliases: []string{"transfer"},
 		Short:   "Command about transaction",
 		Args:    cobra.MinimumNArgs(1),
 		DisableFlagsInUseLine: true,
-		Run: func(cmd *cobra.Command, args []string) {
+		RunE: func(cmd *cobra.Command, args []string) error {
 
 			amountStr := args[0]
 
-			fromAddressStr := viper.GetString("from")
+			fromAddressStr := cli.v.GetString("from")
 			if fromAddressStr == "" || !common.IsHexAddress(fromAddressStr) {
-				fmt.Println("Error: not set from address of owner or from address illegal")
-				fmt.Fprint(os.Stderr, cmd.UsageString())
-				return
+				fmt.Fprint(cli.stderr, cmd.UsageString())
+				return validationErrorf("not set from address of owner or from address illegal")
 			}
 			fromAddress := common.HexToAddress(fromAddressStr)
 
 			toAddressStr, err := cmd.Flags().GetString("to")
 			if err != nil {
-				fmt.Println("Error: required flag(s) \"to\" not set")
-				fmt.Fprint(os.Stderr, cmd.UsageString())
-				return
+				fmt.Fprint(cli.stderr, cmd.UsageString())
+				return validationErrorf("required flag(s) \"to\" not set")
 			}
 			if !common.IsHexAddress(toAddressStr) {
-				fmt.Println("Error: illegal to address", toAddressStr)
-				return
+				return validationErrorf("illegal to address %s", toAddressStr)
 			}
 			toAddress := common.HexToAddress(toAddressStr)
 
-			nowait, _ := cmd.Flags().GetBool("nowait")
-			cli.pay(fromAddress, toAddress, amountStr, nowait)
+			ownerAddress := fromAddress
+			if cmd.Flags().Changed("owner") {
+				ownerAddressStr, _ := cmd.Flags().GetString("owner")
+				if !common.IsHexAddress(ownerAddressStr) {
+					return validationErrorf("illegal owner address %s", ownerAddressStr)
+				}
+				ownerAddress = common.HexToAddress(ownerAddressStr)
+			}
+
+			var options payOptions
+			options.nowait, _ = cmd.Flags().GetBool("nowait")
+			options.call, _ = cmd.Flags().GetBool("call")
+			if cmd.Flags().Changed("call-data") {
+				dataStr, _ := cmd.Flags().GetString("call-data")
+				options.data, err = parseCallData(dataStr)
+				if err != nil {
+					return validationErrorf("illegal call data %s: %v", dataStr, err)
+				}
+				options.call = true
+			}
+			if options.call && cli.mode != cli.blockchain.ModeERC20() {
+				return validationErrorf("%v", cli.blockchain.errOnlyERC20())
+			}
+
+			options.unsafe, _ = cmd.Flags().GetBool("unsafe")
+			if cmd.Flags().Changed("data") {
+				if cli.mode != cli.blockchain.ModeERC721() {
+					return validationErrorf("%v", cli.blockchain.errOnlyERC721())
+				}
+				if options.unsafe {
+					return validationErrorf("the data is only sent by safeTransferFrom, can not be used with --unsafe")
+				}
+				dataStr, _ := cmd.Flags().GetString("data")
+				options.data, err = parseCallData(dataStr)
+				if err != nil {
+					return validationErrorf("illegal data %s: %v", dataStr, err)
+				}
+			}
 
-			return
+			return cli.pay(fromAddress, ownerAddress, toAddress, amountStr, options)
 		},
 	}
 
 	cmd.Flags().StringP("to", "t", "", "the address pay to")
 	cmd.MarkFlagRequired("to")
+	cmd.Flags().String("owner", "", "pay the tokens of owner which the from address is allowed or approved to spend")
+	cmd.Flags().Bool("call", false, "pay by transferAndCall of ERC1363, the to address should implement IERC1363Receiver")
+	cmd.Flags().String("call-data", "", "pay by transferAndCall of ERC1363 with the calldata, 0x hex or @file")
+	cmd.Flags().String("data", "", fmt.Sprintf("the data sent to the receiver by safeTransferFrom, 0x hex or @file, only for %s", cli.blockchain.ModeERC721()))
+	cmd.Flags().Bool("unsafe", false, fmt.Sprintf("transfer by transferFrom without checking the receiver contract, only for %s", cli.blockchain.ModeERC721()))
 	cmd.Flags().Bool("nowait", false, "do not wait for tx to be mined")
 
 	return cmd
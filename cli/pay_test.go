@@ -1,6 +1,12 @@
 package cli
 
-import "testing"
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
 
 func TestTx(t *testing.T) {
 	cli := NewCLI()
@@ -8,3 +14,59 @@ func TestTx(t *testing.T) {
 	cli.TestCommand("tx pay 5 --to 0x6a038842f9E9010624eAeB5f30ec5004C05EE21D")
 
 }
+
+func TestPayOwner(t *testing.T) {
+	cli := NewCLI()
+
+	cli.TestCommand("pay 5 --to 0x6a038842f9E9010624eAeB5f30ec5004C05EE21D --owner 0xeF0b04a14e62434a99C4aF28C6dAb52ba9B1C8F3")
+}
+
+func TestPayCall(t *testing.T) {
+	cli := NewCLI()
+
+	cli.TestCommand("pay 5 --to 0x6a038842f9E9010624eAeB5f30ec5004C05EE21D --call")
+	cli.TestCommand("pay 5 --to 0x6a038842f9E9010624eAeB5f30ec5004C05EE21D --call-data 0x01020304")
+}
+
+func TestParseCallData(t *testing.T) {
+	dir, err := ioutil.TempDir("", "calldata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "data.txt")
+	if err := ioutil.WriteFile(file, []byte("0x0102\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		in   string
+		want []byte
+		ok   bool
+	}{
+		{"0x01020304", []byte{1, 2, 3, 4}, true},
+		{"0x", []byte{}, true},
+		{"01020304", nil, false},
+		{"0xzz", nil, false},
+		{"@" + file, []byte{1, 2}, true},
+		{"@" + file + ".missing", nil, false},
+	}
+	for _, tt := range tests {
+		got, err := parseCallData(tt.in)
+		if (err == nil) != tt.ok {
+			t.Errorf("parseCallData(%s): want ok %v, got error %v", tt.in, tt.ok, err)
+			continue
+		}
+		if tt.ok && !bytes.Equal(got, tt.want) {
+			t.Errorf("parseCallData(%s): want %x, got %x", tt.in, tt.want, got)
+		}
+	}
+}
+
+func TestPayNFT(t *testing.T) {
+	cli := NewCLI()
+
+	cli.TestCommand("pay 10 --to 0x6a038842f9E9010624eAeB5f30ec5004C05EE21D --mode NRC7 --data 0x01")
+	cli.TestCommand("pay 10 --to 0x6a038842f9E9010624eAeB5f30ec5004C05EE21D --mode NRC7 --unsafe")
+}
@@ -6,155 +6,198 @@ import (
 	"math/big"
 	"time"
 
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/newtonproject/tokencommander/contracts/ERC20"
-	"github.com/newtonproject/tokencommander/contracts/ERC721"
+	"github.com/newtonproject/tokencommander/token"
 )
 
 // GasFail GasFail info from SuggestGasPrice
-var GasFail = "failed to estimate gas needed: gas required exceeds allowance or always failing transaction"
+var GasFail = token.GasFail
 
 // TxFailAlways Replacement information GasFail
-var TxFailAlways = "This is a transaction that will always fail. Please check contract and parameters again."
+var TxFailAlways = token.ErrAlwaysFailing.Error()
+
+type payJSON struct {
+	From    string      `json:"from"`
+	Owner   string      `json:"owner,omitempty"`
+	To      string      `json:"to"`
+	Amount  *amountJSON `json:"amount,omitempty"`
+	TokenID string      `json:"tokenID,omitempty"`
+	Call    bool        `json:"call,omitempty"`
+	Safe    bool        `json:"safe,omitempty"`
+	Data    string      `json:"data,omitempty"`
+	Tx      *txJSON     `json:"tx"`
+}
+
+// payOptions is the optional behaviors of pay
+type payOptions struct {
+	nowait bool
+	// call pays NRC6 by transferAndCall of ERC1363 with data
+	call bool
+	// unsafe transfers NRC7 by transferFrom instead of safeTransferFrom
+	unsafe bool
+	// data is sent to the receiver by transferAndCall or safeTransferFrom
+	data []byte
+}
 
 // SubmitTransaction SubmitTransaction
-func (cli *CLI) pay(fromAddress, toAddress common.Address, amountStr string, nowait bool) {
+// The tokens of ownerAddress are paid by TransferFrom if it is not the fromAddress.
+// The tokenID of NRC7 is transferred by safeTransferFrom unless options.unsafe is set.
+func (cli *CLI) pay(fromAddress, ownerAddress, toAddress common.Address, amountStr string, options payOptions) error {
 	var err error
 
-	cli.BuildClient()
-	client := cli.client
-
-	simpleToken, err := cli.GetSimpleToken()
+	tok, err := cli.GetToken()
 	if err != nil {
-		fmt.Println("GetSimpleToken Error: ", err)
-		return
+		return err
 	}
 
-	callOpts := new(bind.CallOpts)
-	callOpts.Pending = true
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+	defer cancel()
 
-	symbol, err := simpleToken.Symbol(callOpts)
+	symbol, err := tok.Symbol(ctx)
 	if err != nil {
-		fmt.Printf("Symbol: Get Symbol Error(%v)\n", err)
-		return
+		return rpcErrorf("Symbol: Get Symbol Error(%v)", err)
 	}
-
-	opts, err := cli.getTransactOpts(fromAddress.String())
+	decimals, err := tok.Decimals(ctx)
 	if err != nil {
-		fmt.Println("GetTransactOpts: ", err)
-		return
+		return rpcErrorf("Decimals: Get decimals Error(%v)", err)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
-	defer cancel()
-	opts.Context = ctx
-
-	var tx *types.Transaction
-	if cli.mode == ModeERC721 {
-		if !IsDecimalString(amountStr) {
-			fmt.Printf("amount(%v) illegal\n", amountStr)
-			return
-		}
 
-		tokenID, ok := getWeiAmountWeiByStringWithDecimals(amountStr, 10, 0)
-		if !ok {
-			fmt.Println("amount invalid: ", amountStr)
-			return
-		}
-		tokenOwner, err := simpleToken.(*ERC721.NRC7Full).OwnerOf(callOpts, tokenID)
+	var amount *big.Int
+	if amountStr == "all" && cli.mode != cli.blockchain.ModeERC721() {
+		amount, err = tok.BalanceOf(ctx, ownerAddress)
 		if err != nil {
-			fmt.Printf("OwnerOf: OwnerOf Error(%v)\n", err)
-			return
-		}
-		if tokenOwner != fromAddress {
-			fmt.Printf("The owner of tokenID(%s) is %s not %s\n", tokenID.String(), tokenOwner.String(), fromAddress.String())
+			return rpcErrorf("Balance: BalanceOf Error(%v)", err)
 		}
-
-		fmt.Printf("Try to transfer tokenID %s to %s from %s ...\n",
-			tokenID, toAddress.String(), fromAddress.String())
-		tx, err = simpleToken.(*ERC721.NRC7Full).TransferFrom(opts, fromAddress, toAddress, tokenID)
-		if err != nil {
-			if GasFail == err.Error() {
-				fmt.Println("SubmitTransaction error: ", TxFailAlways)
-				return
+		if ownerAddress != fromAddress {
+			allowance, err := tok.Allowance(ctx, ownerAddress, fromAddress)
+			if err != nil {
+				return rpcErrorf("Allowance: Get allowance Error(%v)", err)
+			}
+			if allowance.Cmp(amount) < 0 {
+				amount = allowance
 			}
-			fmt.Println("SubmitTransaction error: ", err)
-			return
 		}
-
-		fmt.Printf("Succeed transfer tokenID %s to %s from %s, TxID %s.\n", tokenID, toAddress.String(), fromAddress.String(), tx.Hash().String())
-
 	} else {
-		decimals, err := simpleToken.(*ERC20.BaseToken).Decimals(callOpts)
+		amount, err = token.ParseAmount(amountStr, decimals)
 		if err != nil {
-			fmt.Printf("Decimals: Get decimals Error(%v)\n", err)
-			return
+			return validationErrorf("%v", err)
 		}
+	}
 
-		balance, err := simpleToken.BalanceOf(callOpts, fromAddress)
-		if err != nil {
-			fmt.Printf("Balance: BalanceOf Error(%v)\n", err)
-			return
-		}
+	// refuse before unlocking the wallet if the collection is paused
+	if err := tok.CheckNotPaused(ctx); err != nil {
+		return tokenErrorf(CategoryRPC, "%w", err)
+	}
 
-		amount := big.NewInt(0)
-		if amountStr == "all" {
-			amount = big.NewInt(0).Set(balance)
-		} else {
-			if !IsDecimalString(amountStr) {
-				fmt.Printf("amount(%v) illegal\n", amountStr)
-				return
-			}
-			var ok bool
-			amount, ok = getWeiAmountWeiByStringWithDecimals(amountStr, 10, decimals)
-			if !ok {
-				fmt.Println("amount invalid: ", amountStr)
-				return
-			}
-			if balance.Cmp(amount) < 0 {
-				fmt.Printf("There is not enough balance(%s) to pay the amount(%s) of current transactions.\n",
-					getAmountTextByWeiWithDecimals(balance, decimals), getAmountTextByWeiWithDecimals(amount, decimals))
-				return
-			}
-		}
+	opts, err := cli.getTransactOpts(fromAddress.String())
+	if err != nil {
+		return err
+	}
+	opts.Context = ctx
 
-		fmt.Printf("Try to pay %s %s to %s from %s ...\n",
+	var onBehalf string
+	if ownerAddress != fromAddress {
+		onBehalf = " on behalf of " + ownerAddress.String()
+	}
+	if options.call {
+		onBehalf += fmt.Sprintf(" and call the receiver with %d bytes data", len(options.data))
+	}
+	if cli.mode == cli.blockchain.ModeERC721() {
+		cli.printf("Try to transfer tokenID %s to %s from %s%s ...\n",
+			amount, toAddress.String(), fromAddress.String(), onBehalf)
+	} else {
+		cli.printf("Try to pay %s %s to %s from %s%s ...\n",
 			getAmountTextByWeiWithDecimals(amount, decimals),
-			symbol, toAddress.String(), fromAddress.String())
-		tx, err = simpleToken.(*ERC20.BaseToken).Transfer(opts, toAddress, amount)
-		if err != nil {
-			if GasFail == err.Error() {
-				fmt.Println("SubmitTransaction error: ", TxFailAlways)
-				return
-			}
-			fmt.Println("SubmitTransaction error: ", err)
-			return
-		}
+			symbol, toAddress.String(), fromAddress.String(), onBehalf)
+	}
 
-		fmt.Printf("Succeed submit pay %s %s to %s from %s, TxID %s.\n", getAmountTextByWeiWithDecimals(amount, decimals),
-			symbol, toAddress.String(), fromAddress.String(), tx.Hash().String())
+	safe := cli.mode == cli.blockchain.ModeERC721() && !options.unsafe
+	if cli.mode == cli.blockchain.ModeERC721() && options.unsafe {
+		cli.println("WARNING: transfer by transferFrom without checking the receiver, the tokenID will be lost if the receiver contract can not handle it.")
+	}
+	var tx *types.Transaction
+	if safe {
+		tx, err = tok.SafeTransferFrom(opts, ownerAddress, toAddress, amount, options.data)
+	} else if options.call && ownerAddress != fromAddress {
+		tx, err = tok.TransferFromAndCall(opts, ownerAddress, toAddress, amount, options.data)
+	} else if options.call {
+		tx, err = tok.TransferAndCall(opts, toAddress, amount, options.data)
+	} else if ownerAddress != fromAddress {
+		tx, err = tok.TransferFrom(opts, ownerAddress, toAddress, amount)
+	} else {
+		tx, err = tok.Transfer(opts, toAddress, amount)
+	}
+	if err != nil {
+		return tokenErrorf(CategoryRPC, "SubmitTransaction error: %w", err)
 	}
 
-	if !nowait {
-		fmt.Println("Waiting for transaction to be mined...")
-		txr, err := bind.WaitMined(ctx, client, tx)
+	if cli.mode == cli.blockchain.ModeERC721() {
+		cli.printf("Succeed transfer tokenID %s to %s from %s%s, TxID %s.\n", amount, toAddress.String(), fromAddress.String(), onBehalf, tx.Hash().String())
+	} else {
+		cli.printf("Succeed submit pay %s %s to %s from %s%s, TxID %s.\n", getAmountTextByWeiWithDecimals(amount, decimals),
+			symbol, toAddress.String(), fromAddress.String(), onBehalf, tx.Hash().String())
+	}
+
+	var receipt *token.Receipt
+	if !options.nowait {
+		receipt, err = cli.waitMined(ctx, tx)
 		if err != nil {
-			fmt.Println("WaitMined error: ", err)
-			return
+			return err
 		}
-		showTransactionReceipt(cli.rpcURL, tx.Hash().String())
+	}
 
-		txStatus := "success"
-		if txr.Status != types.ReceiptStatusSuccessful {
-			txStatus = "failed"
+	if cli.isJSON() {
+		result := payJSON{
+			From: fromAddress.String(),
+			To:   toAddress.String(),
+			Tx:   newTxJSON(tx, receipt),
+		}
+		if ownerAddress != fromAddress {
+			result.Owner = ownerAddress.String()
+		}
+		result.Call = options.call
+		result.Safe = safe
+		if len(options.data) > 0 {
+			result.Data = hexutil.Encode(options.data)
 		}
-		fmt.Printf("The tx %s is confirmed and status is %s, with GasFee(%s) = GasPrice(%s) x GasUsed(%d)\n",
-			tx.Hash().String(),
-			txStatus,
-			getWeiAmountTextByUnit(big.NewInt(0).Mul(tx.GasPrice(), big.NewInt(0).SetUint64(txr.GasUsed)), UnitETH),
-			getWeiAmountTextByUnit(tx.GasPrice(), UnitETH),
-			txr.GasUsed)
+		if cli.mode == cli.blockchain.ModeERC721() {
+			result.TokenID = amount.String()
+		} else {
+			result.Amount = newAmountJSON(amount, decimals, symbol)
+		}
+		cli.printJSON(result)
+	}
+
+	if receipt != nil && !receipt.Succeeded() {
+		return revertedErrorf("the tx %s is confirmed but status is failed", tx.Hash().String())
 	}
 
+	return nil
+}
+
+// waitMined waits for tx to be mined and shows the receipt
+func (cli *CLI) waitMined(ctx context.Context, tx *types.Transaction) (*token.Receipt, error) {
+	cli.println("Waiting for transaction to be mined...")
+	receipt, err := token.WaitMined(ctx, cli.client, tx)
+	if err != nil {
+		return nil, rpcErrorf("WaitMined error: %v", err)
+	}
+	if !cli.isJSON() {
+		showTransactionReceipt(cli.logWriter(), cli.rpcURL, tx.Hash().String())
+	}
+
+	txStatus := "success"
+	if !receipt.Succeeded() {
+		txStatus = "failed"
+	}
+	cli.printf("The tx %s is confirmed and status is %s, with GasFee(%s) = GasPrice(%s) x GasUsed(%d)\n",
+		tx.Hash().String(),
+		txStatus,
+		cli.blockchain.getWeiAmountTextByUnit(receipt.GasFee, cli.blockchain.UnitETH()),
+		cli.blockchain.getWeiAmountTextByUnit(receipt.GasPrice, cli.blockchain.UnitETH()),
+		receipt.GasUsed)
+
+	return receipt, nil
 }
@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/newtonproject/tokencommander/token"
+	"github.com/spf13/cobra"
+)
+
+type recoverJSON struct {
+	ContractAddress string      `json:"contractAddress"`
+	Token           string      `json:"token"`
+	Amount          *amountJSON `json:"amount"`
+	Stuck           *amountJSON `json:"stuck"`
+	Tx              *txJSON     `json:"tx"`
+}
+
+func (cli *CLI) buildRecoverCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "recover <tokenAddress> <amount|all>",
+		Short:                 fmt.Sprintf("Recover the tokens sent to the contract address to the owner, only for %s", cli.blockchain.ModeERC20()),
+		Args:                  cobra.MinimumNArgs(2),
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cli.mode != cli.blockchain.ModeERC20() {
+				return validationErrorf("%v", cli.blockchain.errOnlyERC20())
+			}
+			if !common.IsHexAddress(args[0]) {
+				return validationErrorf("illegal token address %s", args[0])
+			}
+			tokenAddress := common.HexToAddress(args[0])
+			if cli.address == "" || !common.IsHexAddress(cli.address) {
+				return configErrorf("not set from address of owner or from address illegal")
+			}
+
+			tok, err := cli.GetToken()
+			if err != nil {
+				return err
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+			defer cancel()
+
+			stuck, err := tok.StuckBalance(ctx, tokenAddress)
+			if err != nil {
+				return tokenErrorf(CategoryRPC, "Get stuck balance error: %w", err)
+			}
+			cli.printf("The contract %s holds %s %s of token %s\n", cli.contractAddress,
+				getAmountTextByWeiWithDecimals(stuck.Balance, stuck.Decimals), stuck.Symbol, tokenAddress.String())
+			if stuck.Balance.Sign() == 0 {
+				return validationErrorf("there is nothing to recover")
+			}
+
+			var amount *big.Int
+			if args[1] == "all" {
+				amount = stuck.Balance
+			} else {
+				amount, err = token.ParseAmount(args[1], stuck.Decimals)
+				if err != nil {
+					return validationErrorf("%v", err)
+				}
+			}
+
+			opts, err := cli.getTransactOpts(cli.address)
+			if err != nil {
+				return err
+			}
+			opts.Context = ctx
+
+			cli.printf("Try to recover %s %s of token %s ...\n",
+				getAmountTextByWeiWithDecimals(amount, stuck.Decimals), stuck.Symbol, tokenAddress.String())
+			tx, err := tok.RecoverERC20(opts, tokenAddress, amount)
+			if err != nil {
+				return tokenErrorf(CategoryRPC, "recover error(%w)", err)
+			}
+			cli.printf("Succeed submit recover, TxID %s.\n", tx.Hash().String())
+
+			receipt, err := cli.waitMined(ctx, tx)
+			if err != nil {
+				return err
+			}
+
+			if stuck, err = tok.StuckBalance(ctx, tokenAddress); err != nil {
+				return tokenErrorf(CategoryRPC, "Get stuck balance error: %w", err)
+			}
+			cli.printf("The contract holds %s %s of token %s now\n",
+				getAmountTextByWeiWithDecimals(stuck.Balance, stuck.Decimals), stuck.Symbol, tokenAddress.String())
+
+			if cli.isJSON() {
+				cli.printJSON(recoverJSON{
+					ContractAddress: cli.contractAddress,
+					Token:           tokenAddress.String(),
+					Amount:          newAmountJSON(amount, stuck.Decimals, stuck.Symbol),
+					Stuck:           newAmountJSON(stuck.Balance, stuck.Decimals, stuck.Symbol),
+					Tx:              newTxJSON(tx, receipt),
+				})
+			}
+
+			if !receipt.Succeeded() {
+				return revertedErrorf("the tx %s is confirmed but status is failed", tx.Hash().String())
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,10 @@
+package cli
+
+import "testing"
+
+func TestRecover(t *testing.T) {
+	cli := NewCLI()
+
+	cli.TestCommand("recover 0xdAC17F958D2ee523a2206206994597C13D831ec7 all")
+	cli.TestCommand("recover 0xdAC17F958D2ee523a2206206994597C13D831ec7 1.5")
+}
@@ -0,0 +1,300 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/newtonproject/tokencommander/token"
+	"github.com/spf13/cobra"
+)
+
+const (
+	roleGrant    = "grant"
+	roleRevoke   = "revoke"
+	roleRenounce = "renounce"
+)
+
+type roleJSON struct {
+	Name    string   `json:"name"`
+	ID      string   `json:"id"`
+	Admin   string   `json:"admin"`
+	Members []string `json:"members"`
+}
+
+type roleListJSON struct {
+	Roles []roleJSON `json:"roles"`
+}
+
+type roleHasJSON struct {
+	Role    string `json:"role"`
+	Account string `json:"account"`
+	HasRole bool   `json:"hasRole"`
+}
+
+type roleChangeJSON struct {
+	Action  string  `json:"action"`
+	Role    string  `json:"role"`
+	Account string  `json:"account"`
+	HasRole bool    `json:"hasRole"`
+	Tx      *txJSON `json:"tx,omitempty"`
+}
+
+func (cli *CLI) buildRoleCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "role [list|has|grant|revoke|renounce]",
+		Short: "Manage the roles of the contract",
+		Args:  cobra.MinimumNArgs(1),
+		RunE:  cli.unknownCommand,
+	}
+
+	cmd.AddCommand(cli.buildRoleListCmd())
+	cmd.AddCommand(cli.buildRoleHasCmd())
+	cmd.AddCommand(cli.buildRoleChangeCmd(roleGrant, "grant role to account"))
+	cmd.AddCommand(cli.buildRoleChangeCmd(roleRevoke, "revoke role from account"))
+	cmd.AddCommand(cli.buildRoleRenounceCmd())
+
+	return cmd
+}
+
+func (cli *CLI) buildRoleListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "list [role]",
+		Short:                 "list the members of role, or of all the roles. Role is name such as MINTER, or hex",
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tok, err := cli.GetToken()
+			if err != nil {
+				return err
+			}
+			ctx := context.Background()
+
+			names := tok.RoleNames()
+			if len(args) > 0 {
+				names = args
+			}
+
+			result := roleListJSON{Roles: make([]roleJSON, 0, len(names))}
+			for _, name := range names {
+				role, err := tok.RoleID(ctx, name)
+				if err != nil {
+					return tokenErrorf(CategoryRPC, "Get role error: %w", err)
+				}
+				admin, err := tok.RoleAdmin(ctx, role)
+				if err != nil {
+					return rpcErrorf("GetRoleAdmin: Get role admin Error(%v)", err)
+				}
+				members, err := tok.RoleMembers(ctx, role)
+				if err != nil {
+					return rpcErrorf("Get role members error: %v", err)
+				}
+
+				r := roleJSON{
+					Name:    tok.RoleName(ctx, role),
+					ID:      hexutil.Encode(role[:]),
+					Admin:   tok.RoleName(ctx, admin),
+					Members: make([]string, 0, len(members)),
+				}
+				for _, member := range members {
+					r.Members = append(r.Members, member.String())
+				}
+				result.Roles = append(result.Roles, r)
+
+				cli.printf("%s(%s) admin %s, %d members:\n", r.Name, r.ID, r.Admin, len(r.Members))
+				for _, member := range r.Members {
+					cli.printf("\t%s\n", member)
+				}
+			}
+
+			if cli.isJSON() {
+				cli.printJSON(result)
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func (cli *CLI) buildRoleHasCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "has <role> <address>",
+		Short:                 "check whether the address has role",
+		Args:                  cobra.MinimumNArgs(2),
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !common.IsHexAddress(args[1]) {
+				return validationErrorf("illegal address %s", args[1])
+			}
+			account := common.HexToAddress(args[1])
+
+			tok, err := cli.GetToken()
+			if err != nil {
+				return err
+			}
+			ctx := context.Background()
+
+			role, err := tok.RoleID(ctx, args[0])
+			if err != nil {
+				return tokenErrorf(CategoryRPC, "Get role error: %w", err)
+			}
+			has, err := tok.HasRole(ctx, role, account)
+			if err != nil {
+				return rpcErrorf("HasRole: Check role Error(%v)", err)
+			}
+
+			name := tok.RoleName(ctx, role)
+			if has {
+				cli.printf("%s has role %s\n", account.String(), name)
+			} else {
+				cli.printf("%s has no role %s\n", account.String(), name)
+			}
+
+			if cli.isJSON() {
+				cli.printJSON(roleHasJSON{Role: name, Account: account.String(), HasRole: has})
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func (cli *CLI) buildRoleChangeCmd(action, short string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   fmt.Sprintf("%s <role> <address>", action),
+		Short:                 short,
+		Args:                  cobra.MinimumNArgs(2),
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !common.IsHexAddress(args[1]) {
+				return validationErrorf("illegal address %s", args[1])
+			}
+
+			return cli.changeRole(cmd, action, args[0], common.HexToAddress(args[1]))
+		},
+	}
+
+	return cmd
+}
+
+func (cli *CLI) buildRoleRenounceCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "renounce <role> [--confirm role]",
+		Short:                 "renounce role of the from address",
+		Args:                  cobra.MinimumNArgs(1),
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cli.address == "" || !common.IsHexAddress(cli.address) {
+				return configErrorf("not set from address or from address illegal")
+			}
+
+			return cli.changeRole(cmd, roleRenounce, args[0], common.HexToAddress(cli.address))
+		},
+	}
+
+	cmd.Flags().String("confirm", "", "the role name to confirm without prompt")
+
+	return cmd
+}
+
+func (cli *CLI) changeRole(cmd *cobra.Command, action, roleName string, account common.Address) error {
+	if cli.address == "" || !common.IsHexAddress(cli.address) {
+		return configErrorf("not set from address or from address illegal")
+	}
+
+	tok, err := cli.GetToken()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+	defer cancel()
+
+	role, err := tok.RoleID(ctx, roleName)
+	if err != nil {
+		return tokenErrorf(CategoryRPC, "Get role error: %w", err)
+	}
+	name := tok.RoleName(ctx, role)
+
+	has, err := tok.HasRole(ctx, role, account)
+	if err != nil {
+		return rpcErrorf("HasRole: Check role Error(%v)", err)
+	}
+	if action == roleGrant && has {
+		cli.printf("%s has role %s already\n", account.String(), name)
+		return cli.showRoleChange(action, name, account, true, nil, nil)
+	}
+	if action == roleRevoke && !has {
+		cli.printf("%s has no role %s already\n", account.String(), name)
+		return cli.showRoleChange(action, name, account, false, nil, nil)
+	}
+
+	if action == roleRenounce {
+		if err := cli.confirmTyped(cmd, fmt.Sprintf("Renounce role %s of %s", name, account.String()), name); err != nil {
+			return err
+		}
+	}
+
+	opts, err := cli.getTransactOpts(cli.address)
+	if err != nil {
+		return err
+	}
+	opts.Context = ctx
+
+	cli.printf("Try to %s role %s of %s ...\n", action, name, account.String())
+
+	var tx *types.Transaction
+	switch action {
+	case roleGrant:
+		tx, err = tok.GrantRole(opts, role, account)
+	case roleRevoke:
+		tx, err = tok.RevokeRole(opts, role, account)
+	case roleRenounce:
+		tx, err = tok.RenounceRole(opts, role)
+	default:
+		return validationErrorf("unknown role action %s", action)
+	}
+	if err != nil {
+		return tokenErrorf(CategoryRPC, "%s role error: %w", action, err)
+	}
+	cli.printf("Succeed submit %s role %s, TxID %s.\n", action, name, tx.Hash().String())
+
+	receipt, err := cli.waitMined(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	has, err = tok.HasRole(ctx, role, account)
+	if err != nil {
+		return rpcErrorf("HasRole: Check role Error(%v)", err)
+	}
+	if err := cli.showRoleChange(action, name, account, has, tx, receipt); err != nil {
+		return err
+	}
+
+	if !receipt.Succeeded() {
+		return revertedErrorf("the tx %s is confirmed but status is failed", tx.Hash().String())
+	}
+
+	return nil
+}
+
+func (cli *CLI) showRoleChange(action, name string, account common.Address, has bool, tx *types.Transaction, receipt *token.Receipt) error {
+	if !cli.isJSON() {
+		return nil
+	}
+	cli.printJSON(roleChangeJSON{
+		Action:  action,
+		Role:    name,
+		Account: account.String(),
+		HasRole: has,
+		Tx:      newTxJSON(tx, receipt),
+	})
+	return nil
+}
@@ -0,0 +1,13 @@
+package cli
+
+import "testing"
+
+func TestRole(t *testing.T) {
+	cli := NewCLI()
+
+	cli.TestCommand("role list")
+	cli.TestCommand("role has MINTER 0xeF0b04a14e62434a99C4aF28C6dAb52ba9B1C8F3")
+	cli.TestCommand("role grant MINTER_ROLE 0xeF0b04a14e62434a99C4aF28C6dAb52ba9B1C8F3")
+	cli.TestCommand("role revoke minter 0xeF0b04a14e62434a99C4aF28C6dAb52ba9B1C8F3")
+	cli.TestCommand("role renounce MINTER --confirm MINTER")
+}
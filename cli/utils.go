@@ -5,37 +5,73 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"math/big"
 	"net/http"
 	"os"
-	"regexp"
 	"strings"
 
 	"github.com/btcsuite/btcutil/base58"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	prompt2 "github.com/ethereum/go-ethereum/console/prompt"
+	"github.com/newtonproject/tokencommander/token"
+	"github.com/spf13/cobra"
 )
 
 // IsDecimalString Check whether amount string is legal amount
-var IsDecimalString = regexp.MustCompile(`^[1-9]\d*$|^0$|^0\.\d*$|^[1-9](\d)*\.(\d)*$`).MatchString
+var IsDecimalString = token.IsDecimalString
 
-func showSuccess(msg string, args ...interface{}) {
-	fmt.Printf(msg+"\n", args...)
+func (cli *CLI) showSuccess(msg string, args ...interface{}) {
+	cli.printf(msg+"\n", args...)
+}
+
+// isTerminal reports whether the prompts read from the terminal
+func (cli *CLI) isTerminal() bool {
+	return cli.stdin == os.Stdin
+}
+
+// promptInput displays the prompt and reads a line of the user input
+func (cli *CLI) promptInput(prompt string) (string, error) {
+	if cli.isTerminal() {
+		return prompt2.Stdin.PromptInput(prompt)
+	}
+	fmt.Fprint(cli.logWriter(), prompt)
+	return cli.readLine()
+}
+
+// promptPassword displays the prompt and reads a line of the user input,
+// without echo if it reads from the terminal
+func (cli *CLI) promptPassword(prompt string) (string, error) {
+	if cli.isTerminal() {
+		return prompt2.Stdin.PromptPassword(prompt)
+	}
+	fmt.Fprint(cli.logWriter(), prompt)
+	return cli.readLine()
+}
+
+func (cli *CLI) readLine() (string, error) {
+	line, err := cli.reader.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
 }
 
 // getPassPhrase retrieves the password associated with an account,
 // requested interactively from the user.
-func getPassPhrase(prompt string, confirmation bool) (string, error) {
+func (cli *CLI) getPassPhrase(prompt string, confirmation bool) (string, error) {
 	// prompt the user for the password
 	if prompt != "" {
-		fmt.Println(prompt)
+		cli.println(prompt)
 	}
-	password, err := prompt2.Stdin.PromptPassword("Enter passphrase (empty for no passphrase): ")
+	password, err := cli.promptPassword("Enter passphrase (empty for no passphrase): ")
 	if err != nil {
 		return "", err
 	}
 	if confirmation {
-		confirm, err := prompt2.Stdin.PromptPassword("Enter same passphrase again: ")
+		confirm, err := cli.promptPassword("Enter same passphrase again: ")
 		if err != nil {
 			return "", err
 		}
@@ -46,6 +82,24 @@ func getPassPhrase(prompt string, confirmation bool) (string, error) {
 	return password, nil
 }
 
+// confirmTyped asks the user to type expected to confirm the action which
+// can not be undone, or takes the typed text from the --confirm flag
+func (cli *CLI) confirmTyped(cmd *cobra.Command, action, expected string) error {
+	typed, _ := cmd.Flags().GetString("confirm")
+	if !cmd.Flags().Changed("confirm") {
+		cli.printf("WARNING: %s can not be undone.\n", action)
+		var err error
+		typed, err = cli.promptInput(fmt.Sprintf("Type %s to confirm: ", expected))
+		if err != nil {
+			return validationErrorf("PromptInput err: %v", err)
+		}
+	}
+	if strings.TrimSpace(typed) != expected {
+		return validationErrorf("the confirmation %q does not match %s, aborted", typed, expected)
+	}
+	return nil
+}
+
 func stringInSlice(str string, list []string) bool {
 	for _, v := range list {
 		if v == str {
@@ -55,25 +109,25 @@ func stringInSlice(str string, list []string) bool {
 	return false
 }
 
-func getWeiAmountTextUnitByUnit(amount *big.Int, unit string) string {
+func (bc BlockChain) getWeiAmountTextUnitByUnit(amount *big.Int, unit string) string {
 	if amount == nil {
-		return fmt.Sprintf("0 %s", UnitWEI)
+		return fmt.Sprintf("0 %s", bc.UnitWEI())
 	}
 	amountStr := amount.String()
 	amountStrLen := len(amountStr)
 	if unit == "" {
 		if amountStrLen <= 18 {
 			// show in WEI
-			unit = UnitWEI
+			unit = bc.UnitWEI()
 		} else {
-			unit = UnitETH
+			unit = bc.UnitETH()
 		}
 	}
 
-	return fmt.Sprintf("%s %s", getWeiAmountTextByUnit(amount, unit), unit)
+	return fmt.Sprintf("%s %s", bc.getWeiAmountTextByUnit(amount, unit), unit)
 }
 
-func getWeiAmountTextByUnit(amount *big.Int, unit string) string {
+func (bc BlockChain) getWeiAmountTextByUnit(amount *big.Int, unit string) string {
 	if amount == nil {
 		return "0"
 	}
@@ -81,7 +135,7 @@ func getWeiAmountTextByUnit(amount *big.Int, unit string) string {
 	amountStrLen := len(amountStr)
 
 	switch unit {
-	case UnitETH:
+	case bc.UnitETH():
 		var amountStrDec, amountStrInt string
 		if amountStrLen <= 18 {
 			amountStrDec = strings.Repeat("0", 18-amountStrLen) + amountStr
@@ -96,7 +150,7 @@ func getWeiAmountTextByUnit(amount *big.Int, unit string) string {
 		}
 		return amountStrInt + "." + amountStrDec
 
-	case UnitWEI:
+	case bc.UnitWEI():
 		return amountStr
 	}
 
@@ -104,50 +158,33 @@ func getWeiAmountTextByUnit(amount *big.Int, unit string) string {
 }
 
 func getWeiAmountWeiByStringWithDecimals(amountStr string, base int, decimals uint8) (*big.Int, bool) {
-	amount := new(big.Int)
-	var aStr string
-	index := strings.Index(amountStr, ".")
-	if index < 0 {
-		aStr = amountStr + strings.Repeat("0", int(decimals))
-		return amount.SetString(aStr, base)
-	} else if index == 0 {
-		amountStr = "0" + amountStr
-		index++
-	}
-
-	if index+1 >= len(amountStr) || len(amountStr[index+1:]) > int(decimals) {
-		return nil, false
-	}
-
-	aStr = amountStr + strings.Repeat("0", int(decimals))
-	aStr = aStr[:index] + aStr[index+1:index+1+int(decimals)]
-	return amount.SetString(aStr, base)
+	return token.ParseAmountBase(amountStr, base, decimals)
 }
 
 func getAmountTextByWeiWithDecimals(amount *big.Int, decimals uint8) string {
-	amountStr := amount.String()
-	len := len(amountStr)
+	return token.FormatAmount(amount, decimals)
+}
 
-	if len <= int(decimals) {
-		aStr := strings.TrimRight(amountStr, "0")
-		if aStr == "" {
-			return "0"
+// parseCallData parses the calldata given as 0x hex, or as @file whose
+// content is 0x hex or raw bytes
+func parseCallData(s string) ([]byte, error) {
+	if strings.HasPrefix(s, "@") {
+		b, err := ioutil.ReadFile(s[1:])
+		if err != nil {
+			return nil, err
 		}
-		return "0." + strings.Repeat("0", int(decimals)-len) + aStr
+		text := strings.TrimSpace(string(b))
+		if strings.HasPrefix(text, "0x") {
+			return hexutil.Decode(text)
+		}
+		return b, nil
 	}
 
-	if decimals == 0 {
-		return amountStr[:len-int(decimals)]
-	}
-	aStr := strings.TrimRight(amountStr[len-int(decimals):], "0")
-	if aStr == "" {
-		return amountStr[:len-int(decimals)]
-	}
-	return amountStr[:len-int(decimals)] + "." + aStr
+	return hexutil.Decode(s)
 }
 
 // showTransactionReceipt
-func showTransactionReceipt(url, txStr string) {
+func showTransactionReceipt(w io.Writer, url, txStr string) {
 	var jsonStr = []byte(fmt.Sprintf(`{"jsonrpc":"2.0","method":"eth_getTransactionReceipt","params":["%s"],"id":1}`, txStr))
 	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonStr))
 	req.Header.Set("Content-Type", "application/json")
@@ -156,7 +193,7 @@ func showTransactionReceipt(url, txStr string) {
 
 	resp, err := clientHttp.Do(req)
 	if err != nil {
-		fmt.Println(err)
+		fmt.Fprintln(w, err)
 		return
 	}
 	defer resp.Body.Close()
@@ -164,30 +201,31 @@ func showTransactionReceipt(url, txStr string) {
 	if resp.StatusCode == http.StatusOK {
 		var body json.RawMessage
 		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
-			fmt.Println(err)
+			fmt.Fprintln(w, err)
 			return
 		}
 
 		bodyStr, err := json.MarshalIndent(body, "", "    ")
 		if err != nil {
-			fmt.Println("JSON marshaling failed: ", err)
+			fmt.Fprintln(w, "JSON marshaling failed: ", err)
 			return
 		}
-		fmt.Printf("%s\n", bodyStr)
+		fmt.Fprintf(w, "%s\n", bodyStr)
 
 		return
 	}
 }
 
-func getFaucet(rpcURL, address string) {
-	url := fmt.Sprintf("%s/faucet?address=%s", rpcURL, address)
+func (cli *CLI) getFaucet(address string) {
+	url := fmt.Sprintf("%s/faucet?address=%s", cli.rpcURL, address)
 	resp, err := http.Get(url)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Get error: %v\n", err)
+		fmt.Fprintf(cli.stderr, "Get error: %v\n", err)
 		return
 	}
+	resp.Body.Close()
 	if resp.StatusCode == 200 {
-		fmt.Printf("Get faucet for %s\n", address)
+		cli.printf("Get faucet for %s\n", address)
 	}
 }
 
@@ -9,8 +9,9 @@ func (cli *CLI) buildVersionCmd() *cobra.Command {
 		Use:   "version",
 		Short: "Get version of " + cli.Name + " CLI",
 		DisableFlagsInUseLine: true,
-		Run: func(cmd *cobra.Command, args []string) {
-			showSuccess(cli.version)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cli.showSuccess(cli.version)
+			return nil
 		},
 	}
 
@@ -0,0 +1,70 @@
+package token
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Allowance returns the amount in base units which spender is allowed to
+// spend on behalf of owner
+func (t *Token) Allowance(ctx context.Context, owner, spender common.Address) (*big.Int, error) {
+	if err := t.requireFungible(); err != nil {
+		return nil, err
+	}
+	return t.erc20.Allowance(callOpts(ctx), owner, spender)
+}
+
+// Approve sets the allowance of spender over the tokens of opts.From to amount
+func (t *Token) Approve(opts *bind.TransactOpts, spender common.Address, amount *big.Int) (*types.Transaction, error) {
+	if err := t.requireFungible(); err != nil {
+		return nil, err
+	}
+	if amount == nil || amount.Sign() < 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	tx, err := t.erc20.Approve(opts, spender, amount)
+	return tx, submitError(err)
+}
+
+// IncreaseAllowance adds amount to the allowance of spender over the tokens
+// of opts.From
+func (t *Token) IncreaseAllowance(opts *bind.TransactOpts, spender common.Address, amount *big.Int) (*types.Transaction, error) {
+	if err := t.requireFungible(); err != nil {
+		return nil, err
+	}
+	if amount == nil || amount.Sign() <= 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	tx, err := t.erc20.IncreaseAllowance(opts, spender, amount)
+	return tx, submitError(err)
+}
+
+// DecreaseAllowance subtracts amount from the allowance of spender over the
+// tokens of opts.From. The current allowance is checked before signing.
+func (t *Token) DecreaseAllowance(opts *bind.TransactOpts, spender common.Address, amount *big.Int) (*types.Transaction, error) {
+	if err := t.requireFungible(); err != nil {
+		return nil, err
+	}
+	if amount == nil || amount.Sign() <= 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	allowance, err := t.erc20.Allowance(pendingCallOpts(opts.Context), opts.From, spender)
+	if err != nil {
+		return nil, fmt.Errorf("Allowance: Get allowance Error(%v)", err)
+	}
+	if allowance.Cmp(amount) < 0 {
+		return nil, fmt.Errorf("%w: the allowance(%s) is less than the decreased amount(%s)",
+			ErrInsufficientAllowance, allowance.String(), amount.String())
+	}
+
+	tx, err := t.erc20.DecreaseAllowance(opts, spender, amount)
+	return tx, submitError(err)
+}
@@ -0,0 +1,69 @@
+package token
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+// IsDecimalString Check whether amount string is legal amount
+var IsDecimalString = regexp.MustCompile(`^[1-9]\d*$|^0$|^0\.\d*$|^[1-9](\d)*\.(\d)*$`).MatchString
+
+// ParseAmount converts the decimal string amountStr to base units with decimals
+func ParseAmount(amountStr string, decimals uint8) (*big.Int, error) {
+	if !IsDecimalString(amountStr) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidAmount, amountStr)
+	}
+	amount, ok := ParseAmountBase(amountStr, 10, decimals)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidAmount, amountStr)
+	}
+	return amount, nil
+}
+
+// ParseAmountBase converts the amountStr in base to base units with decimals,
+// without checking the amountStr with IsDecimalString
+func ParseAmountBase(amountStr string, base int, decimals uint8) (*big.Int, bool) {
+	amount := new(big.Int)
+	var aStr string
+	index := strings.Index(amountStr, ".")
+	if index < 0 {
+		aStr = amountStr + strings.Repeat("0", int(decimals))
+		return amount.SetString(aStr, base)
+	} else if index == 0 {
+		amountStr = "0" + amountStr
+		index++
+	}
+
+	if index+1 >= len(amountStr) || len(amountStr[index+1:]) > int(decimals) {
+		return nil, false
+	}
+
+	aStr = amountStr + strings.Repeat("0", int(decimals))
+	aStr = aStr[:index] + aStr[index+1:index+1+int(decimals)]
+	return amount.SetString(aStr, base)
+}
+
+// FormatAmount formats the base units amount as decimal string with decimals
+func FormatAmount(amount *big.Int, decimals uint8) string {
+	amountStr := amount.String()
+	len := len(amountStr)
+
+	if len <= int(decimals) {
+		aStr := strings.TrimRight(amountStr, "0")
+		if aStr == "" {
+			return "0"
+		}
+		return "0." + strings.Repeat("0", int(decimals)-len) + aStr
+	}
+
+	if decimals == 0 {
+		return amountStr[:len-int(decimals)]
+	}
+	aStr := strings.TrimRight(amountStr[len-int(decimals):], "0")
+	if aStr == "" {
+		return amountStr[:len-int(decimals)]
+	}
+	return amountStr[:len-int(decimals)] + "." + aStr
+}
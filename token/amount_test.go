@@ -0,0 +1,58 @@
+package token
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		amount   string
+		decimals uint8
+		want     string
+	}{
+		{"1", 18, "1000000000000000000"},
+		{"0.5", 1, "5"},
+		{"10.01", 2, "1001"},
+		{"0", 8, "0"},
+		{"123", 0, "123"},
+	}
+	for _, tt := range tests {
+		got, err := ParseAmount(tt.amount, tt.decimals)
+		if err != nil {
+			t.Errorf("ParseAmount(%s, %d) error: %v", tt.amount, tt.decimals, err)
+			continue
+		}
+		if got.String() != tt.want {
+			t.Errorf("ParseAmount(%s, %d): want %s, got %s", tt.amount, tt.decimals, tt.want, got.String())
+		}
+	}
+
+	for _, amount := range []string{"", "abc", "-1", "+1", "01", "0.001", "0x10", "1e3"} {
+		if _, err := ParseAmount(amount, 2); !errors.Is(err, ErrInvalidAmount) {
+			t.Errorf("ParseAmount(%s, 2): want ErrInvalidAmount, got %v", amount, err)
+		}
+	}
+}
+
+func TestFormatAmount(t *testing.T) {
+	tests := []struct {
+		amount   int64
+		decimals uint8
+		want     string
+	}{
+		{0, 18, "0"},
+		{5, 1, "0.5"},
+		{1001, 2, "10.01"},
+		{1000, 2, "10"},
+		{123, 0, "123"},
+		{1, 3, "0.001"},
+	}
+	for _, tt := range tests {
+		got := FormatAmount(big.NewInt(tt.amount), tt.decimals)
+		if got != tt.want {
+			t.Errorf("FormatAmount(%d, %d): want %s, got %s", tt.amount, tt.decimals, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package token
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// GetApproved returns the account approved to transfer a non-fungible
+// tokenID, the zero address if none
+func (t *Token) GetApproved(ctx context.Context, tokenID *big.Int) (common.Address, error) {
+	if err := t.requireNonFungible(); err != nil {
+		return common.Address{}, err
+	}
+	return t.erc721.GetApproved(callOpts(ctx), tokenID)
+}
+
+// IsApprovedForAll reports whether operator is allowed to manage all the
+// non-fungible tokens of owner
+func (t *Token) IsApprovedForAll(ctx context.Context, owner, operator common.Address) (bool, error) {
+	if err := t.requireNonFungible(); err != nil {
+		return false, err
+	}
+	return t.erc721.IsApprovedForAll(callOpts(ctx), owner, operator)
+}
+
+// ApproveTokenID approves to to transfer the non-fungible tokenID, or clears
+// the approval if to is the zero address. opts.From should be the owner or
+// an operator of the owner, which is checked before signing.
+func (t *Token) ApproveTokenID(opts *bind.TransactOpts, to common.Address, tokenID *big.Int) (*types.Transaction, error) {
+	if err := t.requireNonFungible(); err != nil {
+		return nil, err
+	}
+	if tokenID == nil || tokenID.Sign() < 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	callOpts := pendingCallOpts(opts.Context)
+	owner, err := t.erc721.OwnerOf(callOpts, tokenID)
+	if err != nil {
+		return nil, fmt.Errorf("OwnerOf: OwnerOf Error(%v)", err)
+	}
+	if to == owner {
+		return nil, fmt.Errorf("%w: %s is the owner of tokenID(%s)", ErrSelfApproval, to.String(), tokenID.String())
+	}
+	if owner != opts.From {
+		isOperator, err := t.erc721.IsApprovedForAll(callOpts, owner, opts.From)
+		if err != nil {
+			return nil, fmt.Errorf("IsApprovedForAll: Get operator Error(%v)", err)
+		}
+		if !isOperator {
+			return nil, fmt.Errorf("%w: the owner of tokenID(%s) is %s, and %s is not operator of the owner",
+				ErrNotTokenOwner, tokenID.String(), owner.String(), opts.From.String())
+		}
+	}
+
+	tx, err := t.erc721.Approve(opts, to, tokenID)
+	return tx, submitError(err)
+}
+
+// SetApprovalForAll allows or disallows operator to manage all the
+// non-fungible tokens of opts.From
+func (t *Token) SetApprovalForAll(opts *bind.TransactOpts, operator common.Address, approved bool) (*types.Transaction, error) {
+	if err := t.requireNonFungible(); err != nil {
+		return nil, err
+	}
+	if operator == opts.From {
+		return nil, fmt.Errorf("%w: the operator is the from address %s", ErrSelfApproval, operator.String())
+	}
+
+	tx, err := t.erc721.SetApprovalForAll(opts, operator, approved)
+	return tx, submitError(err)
+}
@@ -0,0 +1,342 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Payment is one transfer of a batch
+type Payment struct {
+	To     common.Address
+	Amount *big.Int
+}
+
+// BatchResult is the result of one payment of a batch
+type BatchResult struct {
+	Payment
+	Nonce uint64
+	Tx    *types.Transaction
+	// Receipt is nil if the batch does not wait for the tx to be mined
+	Receipt *Receipt
+}
+
+// CheckBatch returns the total amount of payments and checks that from holds it
+func (t *Token) CheckBatch(ctx context.Context, from common.Address, payments []Payment) (*big.Int, error) {
+	if err := t.requireFungible(); err != nil {
+		return nil, err
+	}
+
+	totalAmount := big.NewInt(0)
+	for _, p := range payments {
+		totalAmount.Add(totalAmount, p.Amount)
+	}
+	if totalAmount.Cmp(big.NewInt(0)) <= 0 {
+		return nil, fmt.Errorf("%w: total pay amount is zero", ErrInvalidAmount)
+	}
+
+	if err := t.CheckTransferEnabled(ctx, from); err != nil {
+		return nil, err
+	}
+
+	balance, err := t.erc20.BalanceOf(pendingCallOpts(ctx), from)
+	if err != nil {
+		return nil, err
+	}
+	if balance.Cmp(totalAmount) < 0 {
+		return nil, ErrInsufficientBalance
+	}
+
+	return totalAmount, nil
+}
+
+// BatchTransfer broadcasts payments in order with sequential nonces from
+// nonce. progress is called after each tx is broadcast, and again with the
+// receipt once mined if wait. opts should sign without prompt, see
+// NewBatchKeyedTransactorByAccount of the CLI. The results of the
+// broadcast payments are returned with the first error.
+func (t *Token) BatchTransfer(opts *bind.TransactOpts, payments []Payment, nonce uint64, wait bool,
+	progress func(*BatchResult)) ([]*BatchResult, error) {
+	if err := t.requireFungible(); err != nil {
+		return nil, err
+	}
+	if opts.Context == nil {
+		return nil, errors.New("context not set")
+	}
+
+	results := make([]*BatchResult, 0, len(payments))
+	for _, p := range payments {
+		opts.Nonce = big.NewInt(0).SetUint64(nonce)
+		tx, err := t.erc20.Transfer(opts, p.To, p.Amount)
+		if err != nil {
+			return results, submitError(err)
+		}
+
+		result := &BatchResult{Payment: p, Nonce: nonce, Tx: tx}
+		results = append(results, result)
+		if progress != nil {
+			progress(result)
+		}
+
+		if wait {
+			result.Receipt, err = WaitMined(opts.Context, t.backend, tx)
+			if err != nil {
+				return results, err
+			}
+			if progress != nil {
+				progress(result)
+			}
+		}
+
+		nonce++
+	}
+
+	return results, nil
+}
+
+// NFTMint is one mint of a batch of a non-fungible token
+type NFTMint struct {
+	To       common.Address
+	TokenURI string
+}
+
+// BatchMintResult is the result of one mint of a batch
+type BatchMintResult struct {
+	NFTMint
+	Nonce uint64
+	Tx    *types.Transaction
+	// Receipt is nil until the tx is mined
+	Receipt *Receipt
+	// TokenIDs are decoded from every Transfer log of the receipt
+	TokenIDs []*big.Int
+}
+
+// CheckBatchMint checks that minter has the minter role and the token is
+// not paused, once for the whole batch
+func (t *Token) CheckBatchMint(ctx context.Context, minter common.Address) error {
+	if err := t.requireNonFungible(); err != nil {
+		return err
+	}
+	if err := t.CheckNotPaused(ctx); err != nil {
+		return err
+	}
+
+	isMinter, err := t.IsMinter(ctx, minter)
+	if err != nil {
+		return fmt.Errorf("check minter error(%v)", err)
+	}
+	if !isMinter {
+		return fmt.Errorf("%w: the from address(%s) is not minter", ErrNotMinter, minter.String())
+	}
+
+	return nil
+}
+
+// BatchMint broadcasts mints in order with sequential nonces from nonce,
+// then waits for the txs to be mined in order and decodes the minted
+// tokenIDs. progress is called after each tx is broadcast, and again with
+// the receipt once mined. The checks of CheckBatchMint are not repeated for
+// each mint. opts should sign without prompt, see
+// NewBatchKeyedTransactorByAccount of the CLI. The results of the broadcast
+// mints are returned with the first error.
+func (t *Token) BatchMint(opts *bind.TransactOpts, mints []NFTMint, nonce uint64,
+	progress func(*BatchMintResult)) ([]*BatchMintResult, error) {
+	if err := t.requireNonFungible(); err != nil {
+		return nil, err
+	}
+	if opts.Context == nil {
+		return nil, errors.New("context not set")
+	}
+
+	var broadcastErr error
+	results := make([]*BatchMintResult, 0, len(mints))
+	for _, m := range mints {
+		opts.Nonce = big.NewInt(0).SetUint64(nonce)
+		var tx *types.Transaction
+		var err error
+		if m.TokenURI == "" {
+			tx, err = t.erc721.Mint(opts, m.To)
+		} else {
+			tx, err = t.erc721.MintWithTokenURI(opts, m.To, m.TokenURI)
+		}
+		if err != nil {
+			// still wait for the broadcast txs
+			broadcastErr = submitError(err)
+			break
+		}
+
+		result := &BatchMintResult{NFTMint: m, Nonce: nonce, Tx: tx}
+		results = append(results, result)
+		if progress != nil {
+			progress(result)
+		}
+
+		nonce++
+	}
+
+	for _, result := range results {
+		receipt, err := WaitMined(opts.Context, t.backend, result.Tx)
+		if err != nil {
+			return results, err
+		}
+		result.Receipt = receipt
+		if receipt.Succeeded() {
+			result.TokenIDs, err = t.MintedTokenIDs(receipt)
+			if err != nil {
+				return results, err
+			}
+		}
+		if progress != nil {
+			progress(result)
+		}
+	}
+
+	return results, broadcastErr
+}
+
+// NFTPayment is one transfer of a batch of a non-fungible token
+type NFTPayment struct {
+	To      common.Address
+	TokenID *big.Int
+}
+
+// BatchNFTResult is the result of one transfer of a batch of a
+// non-fungible token
+type BatchNFTResult struct {
+	NFTPayment
+	Nonce uint64
+	Tx    *types.Transaction
+	// Receipt is nil if the batch does not wait for the tx to be mined
+	Receipt *Receipt
+}
+
+// CheckBatchNFT checks the payments of a non-fungible token before any tx
+// is signed: no tokenID is paid twice, from owns every tokenID, the token
+// is not paused, and the recipient contracts accept the tokens by
+// onERC721Received. The offending tokenIDs are listed in the error.
+func (t *Token) CheckBatchNFT(ctx context.Context, from common.Address, payments []NFTPayment) error {
+	if err := t.requireNonFungible(); err != nil {
+		return err
+	}
+	if len(payments) == 0 {
+		return errors.New("no payment")
+	}
+
+	seen := make(map[string]bool, len(payments))
+	var duplicates []string
+	for _, p := range payments {
+		if p.To == (common.Address{}) {
+			return fmt.Errorf("%w: the recipient of tokenID %s", ErrZeroAddress, p.TokenID.String())
+		}
+		id := p.TokenID.String()
+		if seen[id] {
+			duplicates = append(duplicates, id)
+		}
+		seen[id] = true
+	}
+	if len(duplicates) > 0 {
+		return fmt.Errorf("%w: %s", ErrDuplicateTokenID, strings.Join(duplicates, ","))
+	}
+
+	if err := t.CheckNotPaused(ctx); err != nil {
+		return err
+	}
+
+	var notExists, notOwned []string
+	for _, p := range payments {
+		owner, err := t.erc721.OwnerOf(pendingCallOpts(ctx), p.TokenID)
+		if err != nil {
+			// ownerOf reverts for the tokenID not minted or burned
+			if _, ok := RevertReason(err); ok {
+				notExists = append(notExists, p.TokenID.String())
+				continue
+			}
+			return fmt.Errorf("OwnerOf: OwnerOf Error(%v)", err)
+		}
+		if owner != from {
+			notOwned = append(notOwned, p.TokenID.String())
+		}
+	}
+	if len(notExists) > 0 {
+		return fmt.Errorf("%w: %s", ErrTokenNotExists, strings.Join(notExists, ","))
+	}
+	if len(notOwned) > 0 {
+		return fmt.Errorf("%w: %s not owned by %s", ErrNotTokenOwner, strings.Join(notOwned, ","), from.String())
+	}
+
+	// the transfers to contracts are simulated as safeTransferFrom reverts
+	// if the contract does not accept the token
+	isContract := make(map[common.Address]bool)
+	opts := &bind.TransactOpts{From: from, Context: ctx}
+	for _, p := range payments {
+		contract, ok := isContract[p.To]
+		if !ok {
+			code, err := t.backend.CodeAt(ctx, p.To, nil)
+			if err != nil {
+				return fmt.Errorf("CodeAt: Get code Error(%v)", err)
+			}
+			contract = len(code) > 0
+			isContract[p.To] = contract
+		}
+		if !contract {
+			continue
+		}
+		if err := t.simulate(opts, "safeTransferFrom", from, p.To, p.TokenID); err != nil {
+			return fmt.Errorf("tokenID %s to %s: %w", p.TokenID.String(), p.To.String(), err)
+		}
+	}
+
+	return nil
+}
+
+// BatchTransferNFT broadcasts payments of a non-fungible token from
+// opts.From by safeTransferFrom in order with sequential nonces from nonce.
+// progress is called after each tx is broadcast, and again with the receipt
+// once mined if wait. The payments should be checked by CheckBatchNFT.
+// opts should sign without prompt, see NewBatchKeyedTransactorByAccount of
+// the CLI. The results of the broadcast payments are returned with the
+// first error.
+func (t *Token) BatchTransferNFT(opts *bind.TransactOpts, payments []NFTPayment, nonce uint64, wait bool,
+	progress func(*BatchNFTResult)) ([]*BatchNFTResult, error) {
+	if err := t.requireNonFungible(); err != nil {
+		return nil, err
+	}
+	if opts.Context == nil {
+		return nil, errors.New("context not set")
+	}
+
+	results := make([]*BatchNFTResult, 0, len(payments))
+	for _, p := range payments {
+		opts.Nonce = big.NewInt(0).SetUint64(nonce)
+		tx, err := t.erc721.SafeTransferFrom(opts, opts.From, p.To, p.TokenID)
+		if err != nil {
+			return results, submitError(err)
+		}
+
+		result := &BatchNFTResult{NFTPayment: p, Nonce: nonce, Tx: tx}
+		results = append(results, result)
+		if progress != nil {
+			progress(result)
+		}
+
+		if wait {
+			result.Receipt, err = WaitMined(opts.Context, t.backend, tx)
+			if err != nil {
+				return results, err
+			}
+			if progress != nil {
+				progress(result)
+			}
+		}
+
+		nonce++
+	}
+
+	return results, nil
+}
@@ -0,0 +1,84 @@
+package token
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Burn destroys amount in base units of a fungible token held by opts.From,
+// or the tokenID amount of a non-fungible token which opts.From owns or is
+// approved for. The balance or approval is checked before signing.
+func (t *Token) Burn(opts *bind.TransactOpts, amount *big.Int) (*types.Transaction, error) {
+	if t.Kind == NonFungible {
+		if amount == nil || amount.Sign() < 0 {
+			return nil, ErrInvalidAmount
+		}
+		owner, err := t.erc721.OwnerOf(pendingCallOpts(opts.Context), amount)
+		if err != nil {
+			return nil, fmt.Errorf("OwnerOf: OwnerOf Error(%v)", err)
+		}
+		return t.BurnFrom(opts, owner, amount)
+	}
+
+	if err := t.CheckBurn(opts, amount); err != nil {
+		return nil, err
+	}
+	tx, err := t.erc20.Burn(opts, amount)
+	return tx, submitError(err)
+}
+
+// BurnFrom destroys amount in base units of a fungible token held by owner
+// with the allowance of opts.From, or the tokenID amount of a non-fungible
+// token owned by owner which opts.From is approved for.
+// The allowance or approval is checked before signing.
+func (t *Token) BurnFrom(opts *bind.TransactOpts, owner common.Address, amount *big.Int) (*types.Transaction, error) {
+	if t.Kind == Fungible && owner == opts.From {
+		return t.Burn(opts, amount)
+	}
+	if err := t.CheckBurnFrom(opts, owner, amount); err != nil {
+		return nil, err
+	}
+
+	var tx *types.Transaction
+	var err error
+	if t.Kind == NonFungible {
+		tx, err = t.erc721.Burn(opts, amount)
+	} else {
+		tx, err = t.erc20.BurnFrom(opts, owner, amount)
+	}
+
+	return tx, submitError(err)
+}
+
+// CheckBurn checks that opts.From holds amount of a fungible token.
+// Unlike CheckTransfer, the transfer enabled flag is not checked, as the
+// burn is not locked with the transfer.
+func (t *Token) CheckBurn(opts *bind.TransactOpts, amount *big.Int) error {
+	if err := t.requireFungible(); err != nil {
+		return err
+	}
+	if amount == nil || amount.Sign() <= 0 {
+		return fmt.Errorf("%w: the amount to burn should be greater than 0", ErrInvalidAmount)
+	}
+
+	return t.checkBalance(pendingCallOpts(opts.Context), opts.From, amount)
+}
+
+// CheckBurnFrom checks that opts.From is allowed to burn amount of a
+// fungible token held by owner, or is approved to burn the tokenID amount
+// of a non-fungible token owned by owner which is not paused.
+// Unlike CheckTransferFrom, the transfer enabled flag is not checked.
+func (t *Token) CheckBurnFrom(opts *bind.TransactOpts, owner common.Address, amount *big.Int) error {
+	if t.Kind == NonFungible {
+		return t.CheckTransferFrom(opts, owner, amount)
+	}
+	if amount == nil || amount.Sign() <= 0 {
+		return fmt.Errorf("%w: the amount to burn should be greater than 0", ErrInvalidAmount)
+	}
+
+	return t.checkAllowance(pendingCallOpts(opts.Context), owner, opts.From, amount)
+}
@@ -0,0 +1,107 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/newtonproject/tokencommander/contracts/ERC20"
+)
+
+// fakeLockedBackend answers the calls of a fungible token whose transfer
+// is not enabled yet, and records the transactions sent
+type fakeLockedBackend struct {
+	Backend
+	abi       abi.ABI
+	balance   *big.Int
+	allowance *big.Int
+	sent      []*types.Transaction
+}
+
+func (b *fakeLockedBackend) PendingCallContract(ctx context.Context, call ethereum.CallMsg) ([]byte, error) {
+	method, err := b.abi.MethodById(call.Data[:4])
+	if err != nil {
+		return nil, err
+	}
+	switch method.Name {
+	case "transferEnabled", "hasRole":
+		return method.Outputs.Pack(false)
+	case "balanceOf":
+		return method.Outputs.Pack(b.balance)
+	case "allowance":
+		return method.Outputs.Pack(b.allowance)
+	case "decimals":
+		return method.Outputs.Pack(uint8(18))
+	}
+	return nil, fmt.Errorf("unexpected call of %s", method.Name)
+}
+
+func (b *fakeLockedBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	b.sent = append(b.sent, tx)
+	return nil
+}
+
+func TestBurnTransferLocked(t *testing.T) {
+	parsed, err := abi.JSON(strings.NewReader(ERC20.BaseTokenABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend := &fakeLockedBackend{abi: parsed, balance: big.NewInt(100), allowance: big.NewInt(50)}
+	tok, err := New(common.Address{}, Fungible, backend)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	holder := common.HexToAddress("0xDC8F76075Db000Fa70fdA3AA2c95d63F22A10a67")
+	spender := common.HexToAddress("0x6a038842f9E9010624eAeB5f30ec5004C05EE21D")
+	newOpts := func(from common.Address) *bind.TransactOpts {
+		return &bind.TransactOpts{
+			From:     from,
+			Nonce:    big.NewInt(0),
+			GasPrice: big.NewInt(1),
+			GasLimit: 100000,
+			Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+				return tx, nil
+			},
+			Context: context.Background(),
+		}
+	}
+
+	if _, err := tok.Transfer(newOpts(holder), spender, big.NewInt(10)); !errors.Is(err, ErrTransferDisabled) {
+		t.Errorf("Transfer: want ErrTransferDisabled, got %v", err)
+	}
+	if _, err := tok.TransferFrom(newOpts(spender), holder, spender, big.NewInt(10)); !errors.Is(err, ErrTransferDisabled) {
+		t.Errorf("TransferFrom: want ErrTransferDisabled, got %v", err)
+	}
+
+	if _, err := tok.Burn(newOpts(holder), big.NewInt(10)); err != nil {
+		t.Errorf("Burn: want no error while transfer locked, got %v", err)
+	}
+	if _, err := tok.BurnFrom(newOpts(spender), holder, big.NewInt(10)); err != nil {
+		t.Errorf("BurnFrom: want no error while transfer locked, got %v", err)
+	}
+	if len(backend.sent) != 2 {
+		t.Errorf("Burn: want 2 transactions sent, got %d", len(backend.sent))
+	}
+
+	if _, err := tok.Burn(newOpts(holder), big.NewInt(0)); !errors.Is(err, ErrInvalidAmount) {
+		t.Errorf("Burn: want ErrInvalidAmount of zero amount, got %v", err)
+	}
+	if _, err := tok.BurnFrom(newOpts(spender), holder, big.NewInt(0)); !errors.Is(err, ErrInvalidAmount) {
+		t.Errorf("BurnFrom: want ErrInvalidAmount of zero amount, got %v", err)
+	}
+	if _, err := tok.Burn(newOpts(holder), big.NewInt(101)); !errors.Is(err, ErrInsufficientBalance) {
+		t.Errorf("Burn: want ErrInsufficientBalance, got %v", err)
+	}
+	if _, err := tok.BurnFrom(newOpts(spender), holder, big.NewInt(51)); !errors.Is(err, ErrInsufficientAllowance) {
+		t.Errorf("BurnFrom: want ErrInsufficientAllowance, got %v", err)
+	}
+}
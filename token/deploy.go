@@ -0,0 +1,72 @@
+package token
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/newtonproject/tokencommander/contracts/ERC20"
+	"github.com/newtonproject/tokencommander/contracts/ERC721"
+)
+
+// DeployParams is the constructor arguments of a token contract
+type DeployParams struct {
+	Kind   Kind
+	Name   string
+	Symbol string
+
+	// NRC6|ERC20 only
+	Decimals        uint8
+	Cap             *big.Int
+	InitialSupply   *big.Int
+	TransferEnabled bool
+	MintingFinished bool
+
+	// NRC7|ERC721 only
+	BaseTokenURI string
+}
+
+// Deploy submits the contract creation transaction of a token
+func Deploy(opts *bind.TransactOpts, backend Backend, params DeployParams) (common.Address, *types.Transaction, error) {
+	if params.Name == "" {
+		return common.Address{}, nil, errors.New("name not set")
+	}
+	if params.Symbol == "" {
+		return common.Address{}, nil, errors.New("symbol not set")
+	}
+
+	var contractAddress common.Address
+	var tx *types.Transaction
+	var err error
+	if params.Kind == NonFungible {
+		contractAddress, tx, _, err = ERC721.DeployNRC7Full(opts, backend, params.Name, params.Symbol, params.BaseTokenURI)
+	} else {
+		if params.Decimals > 18 {
+			return common.Address{}, nil, errors.New("decimals invalid")
+		}
+		if params.Cap == nil || params.InitialSupply == nil {
+			return common.Address{}, nil, errors.New("totalSupply not set")
+		}
+		if params.Cap.Sign() <= 0 || params.InitialSupply.Sign() < 0 {
+			return common.Address{}, nil, ErrInvalidAmount
+		}
+		if params.InitialSupply.Cmp(params.Cap) > 0 {
+			return common.Address{}, nil, fmt.Errorf("%w: the initial supply(%s) exceeds the cap(%s)", ErrCapExceeded,
+				FormatAmount(params.InitialSupply, params.Decimals), FormatAmount(params.Cap, params.Decimals))
+		}
+		if params.MintingFinished && params.InitialSupply.Cmp(params.Cap) != 0 {
+			return common.Address{}, nil, fmt.Errorf("%w: the cap(%s) should be equal to the initial supply(%s)", ErrMintingFinished,
+				FormatAmount(params.Cap, params.Decimals), FormatAmount(params.InitialSupply, params.Decimals))
+		}
+		contractAddress, tx, _, err = ERC20.DeployBaseToken(opts, backend, params.Name, params.Symbol, params.Decimals,
+			params.Cap, params.InitialSupply, params.TransferEnabled, params.MintingFinished)
+	}
+	if err != nil {
+		return common.Address{}, nil, submitError(err)
+	}
+
+	return contractAddress, tx, nil
+}
@@ -0,0 +1,129 @@
+package token
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// DefaultConcurrency is the number of parallel calls of ListTokens if not set
+const DefaultConcurrency = 8
+
+// CollectionToken is a tokenID of a non-fungible token with its index in
+// the collection, owner and URI
+type CollectionToken struct {
+	Index    uint64
+	TokenID  *big.Int
+	Owner    common.Address
+	TokenURI string
+}
+
+// parallel calls fn for the indexes from 0 to n-1 with at most concurrency
+// calls in parallel, and stops at the first error. It returns the number of
+// indexes from 0 which are done without error, with the first error.
+func parallel(ctx context.Context, n, concurrency int, fn func(ctx context.Context, i int) error) (int, error) {
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var once sync.Once
+	var firstErr error
+	done := make([]bool, n)
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if err := fn(ctx, i); err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					continue
+				}
+				done[i] = true
+			}
+		}()
+	}
+
+feed:
+	for i := 0; i < n; i++ {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	count := 0
+	for count < n && done[count] {
+		count++
+	}
+	if count < n && firstErr == nil {
+		firstErr = ctx.Err()
+	}
+	return count, firstErr
+}
+
+// TokenByIndex returns the tokenID at index of all the tokenIDs of a
+// non-fungible token
+func (t *Token) TokenByIndex(ctx context.Context, index uint64) (*big.Int, error) {
+	if err := t.requireNonFungible(); err != nil {
+		return nil, err
+	}
+	return t.erc721.TokenByIndex(callOpts(ctx), big.NewInt(0).SetUint64(index))
+}
+
+// ListTokens returns the tokens of a non-fungible token from index start to
+// end (exclusive) by TokenByIndex, OwnerOf and TokenURI, with at most
+// concurrency tokens fetched in parallel. progress is called with the
+// number of tokens fetched if not nil. On error, the tokens from start
+// which are fetched in order are returned with the error, so the listing
+// can be resumed from start+len(tokens).
+func (t *Token) ListTokens(ctx context.Context, start, end uint64, concurrency int, progress func(fetched int)) ([]CollectionToken, error) {
+	if err := t.requireNonFungible(); err != nil {
+		return nil, err
+	}
+	if end <= start {
+		return nil, nil
+	}
+
+	tokens := make([]CollectionToken, end-start)
+	var mu sync.Mutex
+	fetched := 0
+	count, err := parallel(ctx, len(tokens), concurrency, func(ctx context.Context, i int) error {
+		index := start + uint64(i)
+		tokenID, err := t.erc721.TokenByIndex(callOpts(ctx), big.NewInt(0).SetUint64(index))
+		if err != nil {
+			return fmt.Errorf("TokenByIndex: Get tokenID of index %d Error(%v)", index, err)
+		}
+		owner, err := t.erc721.OwnerOf(callOpts(ctx), tokenID)
+		if err != nil {
+			return fmt.Errorf("OwnerOf: Get owner of tokenID %s Error(%v)", tokenID.String(), err)
+		}
+		tokenURI, err := t.erc721.TokenURI(callOpts(ctx), tokenID)
+		if err != nil {
+			return fmt.Errorf("TokenURI: Get token uri of tokenID %s Error(%v)", tokenID.String(), err)
+		}
+		tokens[i] = CollectionToken{Index: index, TokenID: tokenID, Owner: owner, TokenURI: tokenURI}
+
+		if progress != nil {
+			mu.Lock()
+			fetched++
+			progress(fetched)
+			mu.Unlock()
+		}
+		return nil
+	})
+
+	return tokens[:count], err
+}
@@ -0,0 +1,160 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/newtonproject/tokencommander/contracts/ERC721"
+)
+
+func TestParallel(t *testing.T) {
+	var calls int32
+	count, err := parallel(context.Background(), 100, 4, func(ctx context.Context, i int) error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	})
+	if count != 100 || err != nil || calls != 100 {
+		t.Errorf("parallel: want 100 done, got %d done, %d calls, error %v", count, calls, err)
+	}
+
+	errFailed := errors.New("failed")
+	count, err = parallel(context.Background(), 100, 1, func(ctx context.Context, i int) error {
+		if i == 42 {
+			return errFailed
+		}
+		return nil
+	})
+	if count != 42 || !errors.Is(err, errFailed) {
+		t.Errorf("parallel: want 42 done with error, got %d done, error %v", count, err)
+	}
+
+	count, err = parallel(context.Background(), 0, 4, func(ctx context.Context, i int) error {
+		return errFailed
+	})
+	if count != 0 || err != nil {
+		t.Errorf("parallel of none: got %d done, error %v", count, err)
+	}
+}
+
+// fakeNFTBackend answers the calls of tokensOfOwner, balanceOf and
+// tokenOfOwnerByIndex of one owner
+type fakeNFTBackend struct {
+	Backend
+	abi               abi.ABI
+	tokens            []*big.Int
+	withTokensOfOwner bool
+	// tokensOfOwnerOut is returned by tokensOfOwner without it if not nil
+	tokensOfOwnerOut []byte
+	// tokensOfOwnerErr is returned by tokensOfOwner if not nil
+	tokensOfOwnerErr error
+	balanceCalls     int32
+	failIndex        int64
+}
+
+func (b *fakeNFTBackend) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return []byte{0x01}, nil
+}
+
+func (b *fakeNFTBackend) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	method, err := b.abi.MethodById(call.Data[:4])
+	if err != nil {
+		return nil, err
+	}
+	args, err := method.Inputs.Unpack(call.Data[4:])
+	if err != nil {
+		return nil, err
+	}
+	switch method.Name {
+	case "tokensOfOwner":
+		if b.tokensOfOwnerErr != nil {
+			return nil, b.tokensOfOwnerErr
+		}
+		if !b.withTokensOfOwner {
+			if b.tokensOfOwnerOut != nil {
+				return b.tokensOfOwnerOut, nil
+			}
+			return nil, errors.New("execution reverted")
+		}
+		return method.Outputs.Pack(b.tokens)
+	case "balanceOf":
+		atomic.AddInt32(&b.balanceCalls, 1)
+		return method.Outputs.Pack(big.NewInt(int64(len(b.tokens))))
+	case "tokenOfOwnerByIndex":
+		index := args[1].(*big.Int)
+		if index.Int64() == b.failIndex {
+			return nil, errors.New("connection reset")
+		}
+		return method.Outputs.Pack(b.tokens[index.Int64()])
+	}
+	return nil, fmt.Errorf("unexpected call of %s", method.Name)
+}
+
+func TestTokensOfOwner(t *testing.T) {
+	parsed, err := abi.JSON(strings.NewReader(ERC721.NRC7FullABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokens := make([]*big.Int, 0, 123)
+	for i := 0; i < cap(tokens); i++ {
+		tokens = append(tokens, big.NewInt(int64(1000+i)))
+	}
+	owner := common.HexToAddress("0xDC8F76075Db000Fa70fdA3AA2c95d63F22A10a67")
+
+	for _, withTokensOfOwner := range []bool{true, false} {
+		backend := &fakeNFTBackend{abi: parsed, tokens: tokens, withTokensOfOwner: withTokensOfOwner, failIndex: -1}
+		tok, err := New(common.Address{}, NonFungible, backend)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := tok.TokensOfOwner(context.Background(), owner)
+		if err != nil {
+			t.Fatalf("TokensOfOwner(withTokensOfOwner %v): %v", withTokensOfOwner, err)
+		}
+		if !reflect.DeepEqual(got, tokens) {
+			t.Errorf("TokensOfOwner(withTokensOfOwner %v): want %v, got %v", withTokensOfOwner, tokens, got)
+		}
+
+		backend.failIndex = 77
+		got, err = tok.TokensOfOwner(context.Background(), owner)
+		if withTokensOfOwner && err != nil {
+			t.Errorf("TokensOfOwner: want no error by tokensOfOwner, got %v", err)
+		}
+		if !withTokensOfOwner && (err == nil || got != nil) {
+			t.Errorf("TokensOfOwner by index: want error of index 77, got %v, %v", got, err)
+		}
+	}
+
+	// the contract with a fallback function returns no data
+	backend := &fakeNFTBackend{abi: parsed, tokens: tokens, tokensOfOwnerOut: []byte{}, failIndex: -1}
+	tok, err := New(common.Address{}, NonFungible, backend)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := tok.TokensOfOwner(context.Background(), owner)
+	if err != nil || !reflect.DeepEqual(got, tokens) {
+		t.Errorf("TokensOfOwner of empty data: want the tokens by index, got %v, %v", got, err)
+	}
+
+	// the network errors are returned without walking the index
+	backend = &fakeNFTBackend{abi: parsed, tokens: tokens, tokensOfOwnerErr: errors.New("connection reset"), failIndex: -1}
+	if tok, err = New(common.Address{}, NonFungible, backend); err != nil {
+		t.Fatal(err)
+	}
+	got, err = tok.TokensOfOwner(context.Background(), owner)
+	if err == nil || !strings.Contains(err.Error(), "connection reset") || got != nil {
+		t.Errorf("TokensOfOwner: want the error of tokensOfOwner, got %v, %v", got, err)
+	}
+	if backend.balanceCalls != 0 {
+		t.Errorf("TokensOfOwner: want no walk of the index on network error, got %d balanceOf calls", backend.balanceCalls)
+	}
+}
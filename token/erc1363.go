@@ -0,0 +1,156 @@
+package token
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/newtonproject/tokencommander/contracts/ERC20"
+)
+
+// The ERC165 interface ids of the ERC1363 receiver and spender
+var (
+	ERC1363ReceiverInterfaceID = [4]byte{0x88, 0xa7, 0xca, 0x5c}
+	ERC1363SpenderInterfaceID  = [4]byte{0x7b, 0x04, 0xa2, 0xd0}
+)
+
+// SupportsInterface reports whether the contract at account implements the
+// ERC165 interface id. It is false if account has no code or does not
+// implement ERC165.
+func (t *Token) SupportsInterface(ctx context.Context, account common.Address, interfaceID [4]byte) (bool, error) {
+	code, err := t.backend.CodeAt(ctx, account, nil)
+	if err != nil {
+		return false, err
+	}
+	if len(code) == 0 {
+		return false, nil
+	}
+
+	caller, err := ERC20.NewBaseTokenCaller(account, t.backend)
+	if err != nil {
+		return false, err
+	}
+	// the contract without supportsInterface reverts
+	supported, err := caller.SupportsInterface(callOpts(ctx), interfaceID)
+	if err != nil {
+		return false, nil
+	}
+
+	return supported, nil
+}
+
+func (t *Token) checkERC1363Receiver(ctx context.Context, to common.Address) error {
+	ok, err := t.SupportsInterface(ctx, to, ERC1363ReceiverInterfaceID)
+	if err != nil {
+		return fmt.Errorf("SupportsInterface: Check interface Error(%v)", err)
+	}
+	if !ok {
+		return fmt.Errorf("%w: %s does not implement IERC1363Receiver", ErrNotERC1363Receiver, to.String())
+	}
+	return nil
+}
+
+// TransferAndCall pays amount in base units of a fungible token from
+// opts.From to to, and calls onTransferReceived of to with data. The balance
+// of opts.From and the IERC1363Receiver of to are checked, and the call is
+// simulated to get the revert reason before signing.
+func (t *Token) TransferAndCall(opts *bind.TransactOpts, to common.Address, amount *big.Int, data []byte) (*types.Transaction, error) {
+	if err := t.requireFungible(); err != nil {
+		return nil, err
+	}
+	if err := t.CheckTransfer(opts, amount); err != nil {
+		return nil, err
+	}
+	if err := t.checkERC1363Receiver(ensureContext(opts), to); err != nil {
+		return nil, err
+	}
+
+	var tx *types.Transaction
+	var err error
+	if len(data) == 0 {
+		if err := t.simulate(opts, "transferAndCall", to, amount); err != nil {
+			return nil, err
+		}
+		tx, err = t.erc20.TransferAndCall(opts, to, amount)
+	} else {
+		if err := t.simulate(opts, "transferAndCall0", to, amount, data); err != nil {
+			return nil, err
+		}
+		tx, err = t.erc20.TransferAndCall0(opts, to, amount, data)
+	}
+
+	return tx, submitError(err)
+}
+
+// TransferFromAndCall pays amount in base units of a fungible token from
+// owner to to on behalf of opts.From, and calls onTransferReceived of to
+// with data. The allowance of opts.From, the balance of owner and the
+// IERC1363Receiver of to are checked, and the call is simulated to get the
+// revert reason before signing.
+func (t *Token) TransferFromAndCall(opts *bind.TransactOpts, owner, to common.Address, amount *big.Int, data []byte) (*types.Transaction, error) {
+	if err := t.requireFungible(); err != nil {
+		return nil, err
+	}
+	if err := t.CheckTransferFrom(opts, owner, amount); err != nil {
+		return nil, err
+	}
+	if err := t.checkERC1363Receiver(ensureContext(opts), to); err != nil {
+		return nil, err
+	}
+
+	// the overload with data is transferFromAndCall in the binding
+	var tx *types.Transaction
+	var err error
+	if len(data) == 0 {
+		if err := t.simulate(opts, "transferFromAndCall0", owner, to, amount); err != nil {
+			return nil, err
+		}
+		tx, err = t.erc20.TransferFromAndCall0(opts, owner, to, amount)
+	} else {
+		if err := t.simulate(opts, "transferFromAndCall", owner, to, amount, data); err != nil {
+			return nil, err
+		}
+		tx, err = t.erc20.TransferFromAndCall(opts, owner, to, amount, data)
+	}
+
+	return tx, submitError(err)
+}
+
+// ApproveAndCall sets the allowance of spender over the tokens of opts.From
+// to amount, and calls onApprovalReceived of spender with data. The
+// IERC1363Spender of spender is checked, and the call is simulated to get
+// the revert reason before signing.
+func (t *Token) ApproveAndCall(opts *bind.TransactOpts, spender common.Address, amount *big.Int, data []byte) (*types.Transaction, error) {
+	if err := t.requireFungible(); err != nil {
+		return nil, err
+	}
+	if amount == nil || amount.Sign() < 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	ok, err := t.SupportsInterface(ensureContext(opts), spender, ERC1363SpenderInterfaceID)
+	if err != nil {
+		return nil, fmt.Errorf("SupportsInterface: Check interface Error(%v)", err)
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: %s does not implement IERC1363Spender", ErrNotERC1363Spender, spender.String())
+	}
+
+	var tx *types.Transaction
+	if len(data) == 0 {
+		if err := t.simulate(opts, "approveAndCall", spender, amount); err != nil {
+			return nil, err
+		}
+		tx, err = t.erc20.ApproveAndCall(opts, spender, amount)
+	} else {
+		if err := t.simulate(opts, "approveAndCall0", spender, amount, data); err != nil {
+			return nil, err
+		}
+		tx, err = t.erc20.ApproveAndCall0(opts, spender, amount, data)
+	}
+
+	return tx, submitError(err)
+}
@@ -0,0 +1,75 @@
+package token
+
+import "errors"
+
+// GasFail is the error returned by the node when estimating gas for a
+// transaction that will always fail
+const GasFail = "failed to estimate gas needed: gas required exceeds allowance or always failing transaction"
+
+var (
+	// ErrOnlyFungible is returned when a NRC6|ERC20 only function is called on a NRC7|ERC721 token
+	ErrOnlyFungible = errors.New("only fungible token support")
+	// ErrOnlyNonFungible is returned when a NRC7|ERC721 only function is called on a NRC6|ERC20 token
+	ErrOnlyNonFungible = errors.New("only non-fungible token support")
+
+	// ErrInvalidAmount is returned when an amount can not be converted with the token decimals
+	ErrInvalidAmount = errors.New("amount invalid")
+	// ErrZeroAddress is returned when the zero address is given as an account
+	ErrZeroAddress = errors.New("zero address")
+	// ErrInsufficientBalance is returned when the payer has not enough balance
+	ErrInsufficientBalance = errors.New("insufficient balance")
+	// ErrInsufficientAllowance is returned when the spender has not enough allowance
+	ErrInsufficientAllowance = errors.New("insufficient allowance")
+	// ErrNotTokenOwner is returned when the payer does not own the tokenID
+	ErrNotTokenOwner = errors.New("not owner of tokenID")
+	// ErrTokenNotExists is returned when the tokenID is not minted or burned
+	ErrTokenNotExists = errors.New("tokenID not exists")
+	// ErrDuplicateTokenID is returned when a batch transfers the same tokenID more than once
+	ErrDuplicateTokenID = errors.New("duplicate tokenID")
+	// ErrNotApproved is returned when the spender is not approved to transfer the tokenID
+	ErrNotApproved = errors.New("not approved for tokenID")
+	// ErrSelfApproval is returned when approving the owner of the tokenID, or the sender as operator
+	ErrSelfApproval = errors.New("approve to owner")
+	// ErrNotMinter is returned when the sender has no minter role
+	ErrNotMinter = errors.New("not minter")
+	// ErrNotPauser is returned when the sender has no pauser role
+	ErrNotPauser = errors.New("not pauser")
+	// ErrPaused is returned when transferring, minting or burning a non-fungible token which is paused
+	ErrPaused = errors.New("paused")
+	// ErrNotPaused is returned when unpausing a non-fungible token which is not paused
+	ErrNotPaused = errors.New("not paused")
+	// ErrUnknownRole is returned when the role name is not defined by the contract
+	ErrUnknownRole = errors.New("unknown role")
+	// ErrNotRoleAdmin is returned when the sender has not the admin role of the role
+	ErrNotRoleAdmin = errors.New("not role admin")
+	// ErrNoRole is returned when the sender has not the role to renounce or to call the function
+	ErrNoRole = errors.New("no role")
+	// ErrNotOwner is returned when the sender is not the owner of the contract
+	ErrNotOwner = errors.New("not owner of contract")
+	// ErrTransferDisabled is returned when transferring a fungible token whose transfer is not enabled
+	ErrTransferDisabled = errors.New("transfer disabled")
+	// ErrTransferEnabled is returned when enabling the transfer which is enabled already
+	ErrTransferEnabled = errors.New("transfer enabled already")
+	// ErrMintingFinished is returned when minting a fungible token whose minting is finished
+	ErrMintingFinished = errors.New("minting finished")
+	// ErrCapExceeded is returned when minting more than the cap of total supply
+	ErrCapExceeded = errors.New("cap exceeded")
+
+	// ErrNotERC1363Receiver is returned when the receiver of transferAndCall does not implement IERC1363Receiver
+	ErrNotERC1363Receiver = errors.New("not ERC1363 receiver")
+	// ErrNotERC1363Spender is returned when the spender of approveAndCall does not implement IERC1363Spender
+	ErrNotERC1363Spender = errors.New("not ERC1363 spender")
+
+	// ErrExecutionReverted is returned when the simulated call of a transaction reverts, with the revert reason
+	ErrExecutionReverted = errors.New("execution reverted")
+	// ErrAlwaysFailing replaces the GasFail error returned by the node
+	ErrAlwaysFailing = errors.New("This is a transaction that will always fail. Please check contract and parameters again.")
+)
+
+// submitError converts the error of submitting a transaction
+func submitError(err error) error {
+	if err != nil && err.Error() == GasFail {
+		return ErrAlwaysFailing
+	}
+	return err
+}
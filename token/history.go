@@ -0,0 +1,262 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// The chunk sizes of the block ranges of History and the other log filters
+const (
+	DefaultHistoryChunk = 5000
+	maxFilterChunk      = 100000
+	// the chunk grows if fewer logs are returned
+	growFilterLogs = 1000
+)
+
+// Direction is the direction of the transfers of an account
+type Direction int
+
+// The directions of the transfers
+const (
+	DirectionAll Direction = iota
+	DirectionIn
+	DirectionOut
+)
+
+// TransferEvent is a Transfer event of the token
+type TransferEvent struct {
+	BlockNumber uint64
+	// Time is the timestamp of the block, 0 if the backend can not get the
+	// block headers
+	Time     uint64
+	TxHash   common.Hash
+	LogIndex uint
+	From     common.Address
+	To       common.Address
+	// Value is the amount in base units of a fungible token, or the tokenID
+	// of a non-fungible token
+	Value *big.Int
+}
+
+// HistoryQuery is the query of the Transfer events of an account
+type HistoryQuery struct {
+	Account   common.Address
+	Direction Direction
+	FromBlock uint64
+	// ToBlock is the last block, the latest block if nil
+	ToBlock *uint64
+	// Chunk is the initial number of blocks of a filter request,
+	// DefaultHistoryChunk if 0. The chunk is halved if the request fails,
+	// such as for the log limits of the provider, and grows if few logs
+	// are returned.
+	Chunk uint64
+}
+
+// History returns the Transfer events of the account from FromBlock to
+// ToBlock in order, filtered in adaptive chunks of block ranges. progress
+// is called after each chunk with the range and the number of events found
+// if not nil. On error, the events found before are returned with the
+// error.
+func (t *Token) History(ctx context.Context, q HistoryQuery, progress func(from, to uint64, found int)) ([]TransferEvent, error) {
+	reader, canReadHeaders := t.backend.(headerReader)
+
+	var toBlock uint64
+	if q.ToBlock != nil {
+		toBlock = *q.ToBlock
+	} else {
+		var err error
+		toBlock, err = t.latestBlock(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%v, set the to block", err)
+		}
+	}
+	if q.FromBlock > toBlock {
+		return nil, fmt.Errorf("the from block %d is after the to block %d", q.FromBlock, toBlock)
+	}
+
+	chunk := q.Chunk
+	if chunk == 0 {
+		chunk = DefaultHistoryChunk
+	}
+
+	var events []TransferEvent
+	err := filterChunks(q.FromBlock, toBlock, chunk, func(start, end uint64) (int, error) {
+		found, err := t.filterTransfers(ctx, q.Account, q.Direction, start, end)
+		if err != nil {
+			return 0, err
+		}
+		events = append(events, found...)
+		return len(found), nil
+	}, progress)
+	if err != nil {
+		return events, fmt.Errorf("FilterTransfer: %v", err)
+	}
+
+	if canReadHeaders {
+		if err := t.setEventTimes(ctx, reader, events); err != nil {
+			return events, err
+		}
+	}
+
+	return events, nil
+}
+
+// latestBlock returns the number of the latest block
+func (t *Token) latestBlock(ctx context.Context) (uint64, error) {
+	reader, ok := t.backend.(headerReader)
+	if !ok {
+		return 0, errors.New("the backend can not get the latest block")
+	}
+	header, err := reader.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("HeaderByNumber: Get latest block Error(%v)", err)
+	}
+	return header.Number.Uint64(), nil
+}
+
+// filterChunks calls filter for the blocks from start to end in order, in
+// adaptive chunks of chunk blocks at first. The chunk is halved if filter
+// fails, such as for the log limits of the provider, and doubles if fewer
+// than growFilterLogs logs are found, up to maxFilterChunk blocks. filter
+// returns the number of logs found, and progress is called after each
+// chunk with the range and the number if not nil.
+func filterChunks(start, end, chunk uint64, filter func(start, end uint64) (int, error), progress func(from, to uint64, found int)) error {
+	for start <= end {
+		last := start + chunk - 1
+		if last > end || last < start {
+			last = end
+		}
+
+		found, err := filter(start, last)
+		if err != nil {
+			if chunk > 1 {
+				chunk /= 2
+				continue
+			}
+			return fmt.Errorf("Filter block %d Error(%v)", start, err)
+		}
+		if progress != nil {
+			progress(start, last, found)
+		}
+
+		if found < growFilterLogs && chunk < maxFilterChunk {
+			chunk *= 2
+		}
+		if last == end {
+			break
+		}
+		start = last + 1
+	}
+
+	return nil
+}
+
+// filterTransfers returns the Transfer events of account in the direction
+// from block start to end in order
+func (t *Token) filterTransfers(ctx context.Context, account common.Address, direction Direction, start, end uint64) ([]TransferEvent, error) {
+	opts := &bind.FilterOpts{Start: start, End: &end, Context: ctx}
+	accounts := []common.Address{account}
+
+	var events []TransferEvent
+	filter := func(from, to []common.Address) error {
+		if t.Kind == NonFungible {
+			it, err := t.erc721.FilterTransfer(opts, from, to, nil)
+			if err != nil {
+				return err
+			}
+			defer it.Close()
+			for it.Next() {
+				e := it.Event
+				events = append(events, TransferEvent{BlockNumber: e.Raw.BlockNumber, TxHash: e.Raw.TxHash,
+					LogIndex: e.Raw.Index, From: e.From, To: e.To, Value: e.TokenId})
+			}
+			return it.Error()
+		}
+
+		it, err := t.erc20.FilterTransfer(opts, from, to)
+		if err != nil {
+			return err
+		}
+		defer it.Close()
+		for it.Next() {
+			e := it.Event
+			events = append(events, TransferEvent{BlockNumber: e.Raw.BlockNumber, TxHash: e.Raw.TxHash,
+				LogIndex: e.Raw.Index, From: e.From, To: e.To, Value: e.Value})
+		}
+		return it.Error()
+	}
+
+	if direction != DirectionIn {
+		if err := filter(accounts, nil); err != nil {
+			return nil, err
+		}
+	}
+	if direction != DirectionOut {
+		if err := filter(nil, accounts); err != nil {
+			return nil, err
+		}
+	}
+
+	return sortTransferEvents(events), nil
+}
+
+// sortTransferEvents sorts the events by block and log index, and removes
+// the duplicate events such as the transfers to self found in both
+// directions
+func sortTransferEvents(events []TransferEvent) []TransferEvent {
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].BlockNumber != events[j].BlockNumber {
+			return events[i].BlockNumber < events[j].BlockNumber
+		}
+		return events[i].LogIndex < events[j].LogIndex
+	})
+
+	unique := events[:0]
+	for i, e := range events {
+		if i > 0 && e.TxHash == events[i-1].TxHash && e.LogIndex == events[i-1].LogIndex {
+			continue
+		}
+		unique = append(unique, e)
+	}
+	return unique
+}
+
+// setEventTimes sets the time of the events by the headers of their blocks
+func (t *Token) setEventTimes(ctx context.Context, reader headerReader, events []TransferEvent) error {
+	var blocks []uint64
+	seen := make(map[uint64]bool)
+	for _, e := range events {
+		if !seen[e.BlockNumber] {
+			seen[e.BlockNumber] = true
+			blocks = append(blocks, e.BlockNumber)
+		}
+	}
+
+	times := make(map[uint64]uint64, len(blocks))
+	var mu sync.Mutex
+	_, err := parallel(ctx, len(blocks), DefaultConcurrency, func(ctx context.Context, i int) error {
+		header, err := reader.HeaderByNumber(ctx, big.NewInt(0).SetUint64(blocks[i]))
+		if err != nil {
+			return fmt.Errorf("HeaderByNumber: Get block %d Error(%v)", blocks[i], err)
+		}
+		mu.Lock()
+		times[blocks[i]] = header.Time
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for i := range events {
+		events[i].Time = times[events[i].BlockNumber]
+	}
+	return nil
+}
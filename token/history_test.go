@@ -0,0 +1,124 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// fakeLogBackend answers the Transfer logs of a fungible token, and fails
+// the filter requests of more than limit blocks
+type fakeLogBackend struct {
+	Backend
+	logs     []types.Log
+	latest   uint64
+	limit    uint64
+	requests int
+}
+
+func (b *fakeLogBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if number == nil {
+		number = big.NewInt(0).SetUint64(b.latest)
+	}
+	return &types.Header{Number: number, Time: number.Uint64() * 10}, nil
+}
+
+func (b *fakeLogBackend) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	b.requests++
+	if q.ToBlock.Uint64()-q.FromBlock.Uint64()+1 > b.limit {
+		return nil, errors.New("query returned more than 10000 results")
+	}
+
+	match := func(topics []common.Hash, topic common.Hash) bool {
+		if len(topics) == 0 {
+			return true
+		}
+		for _, t := range topics {
+			if t == topic {
+				return true
+			}
+		}
+		return false
+	}
+	var logs []types.Log
+	for _, log := range b.logs {
+		if log.BlockNumber < q.FromBlock.Uint64() || log.BlockNumber > q.ToBlock.Uint64() {
+			continue
+		}
+		if match(q.Topics[1], log.Topics[1]) && match(q.Topics[2], log.Topics[2]) {
+			logs = append(logs, log)
+		}
+	}
+	return logs, nil
+}
+
+func (b *fakeLogBackend) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return nil, errors.New("not supported")
+}
+
+func TestHistory(t *testing.T) {
+	transferTopic := crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	account := common.HexToAddress("0xDC8F76075Db000Fa70fdA3AA2c95d63F22A10a67")
+	other := common.HexToAddress("0x6a038842f9E9010624eAeB5f30ec5004C05EE21D")
+
+	transfer := func(block uint64, index uint, from, to common.Address, value int64) types.Log {
+		return types.Log{
+			Topics:      []common.Hash{transferTopic, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())},
+			Data:        common.LeftPadBytes(big.NewInt(value).Bytes(), 32),
+			BlockNumber: block,
+			TxHash:      common.BigToHash(big.NewInt(int64(block))),
+			Index:       index,
+		}
+	}
+	backend := &fakeLogBackend{
+		logs: []types.Log{
+			transfer(5, 0, other, account, 1),
+			transfer(5, 1, account, other, 2),
+			transfer(300, 0, account, account, 3),
+			transfer(301, 0, other, other, 4),
+			transfer(999, 2, account, other, 5),
+		},
+		latest: 1000,
+		limit:  100,
+	}
+	tok, err := New(common.Address{}, Fungible, backend)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := tok.History(context.Background(), HistoryQuery{Account: account}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var values []int64
+	for _, e := range events {
+		values = append(values, e.Value.Int64())
+		if e.Time != e.BlockNumber*10 {
+			t.Errorf("History: want time %d of block %d, got %d", e.BlockNumber*10, e.BlockNumber, e.Time)
+		}
+	}
+	if want := []int64{1, 2, 3, 5}; !reflect.DeepEqual(values, want) {
+		t.Errorf("History: want values %v, got %v", want, values)
+	}
+
+	toBlock := uint64(400)
+	events, err = tok.History(context.Background(), HistoryQuery{Account: account, Direction: DirectionIn, ToBlock: &toBlock, Chunk: 50}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 || events[0].Value.Int64() != 1 || events[1].Value.Int64() != 3 {
+		t.Errorf("History in: want values 1 and 3, got %v", events)
+	}
+
+	backend.limit = 0
+	if _, err := tok.History(context.Background(), HistoryQuery{Account: account}, nil); err == nil {
+		t.Error("History: want error when every request fails")
+	}
+}
@@ -0,0 +1,323 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// BatchCaller sends json rpc requests in one batch, such as *rpc.Client
+type BatchCaller interface {
+	BatchCallContext(ctx context.Context, b []rpc.BatchElem) error
+}
+
+// Interface is an ERC165 interface id with its name
+type Interface struct {
+	Name string
+	ID   [4]byte
+}
+
+// The ERC165 interfaces shown by Info
+var (
+	fungibleInterfaces = []Interface{
+		{"ERC165", [4]byte{0x01, 0xff, 0xc9, 0xa7}},
+		{"ERC20", [4]byte{0x36, 0x37, 0x2b, 0x07}},
+		{"ERC1363", [4]byte{0xb0, 0x20, 0x2a, 0x11}},
+		{"AccessControl", [4]byte{0x79, 0x65, 0xdb, 0x0b}},
+	}
+	nonFungibleInterfaces = []Interface{
+		{"ERC165", [4]byte{0x01, 0xff, 0xc9, 0xa7}},
+		{"ERC721", [4]byte{0x80, 0xac, 0x58, 0xcd}},
+		{"ERC721Metadata", [4]byte{0x5b, 0x5e, 0x13, 0x9f}},
+		{"ERC721Enumerable", [4]byte{0x78, 0x0e, 0x9d, 0x63}},
+		{"AccessControl", [4]byte{0x79, 0x65, 0xdb, 0x0b}},
+		{"AccessControlEnumerable", [4]byte{0x5a, 0x05, 0x18, 0x0f}},
+	}
+)
+
+// roleIDs are the roles of RoleNames by name
+var roleIDs = map[string][32]byte{
+	RoleDefaultAdmin: {},
+	RoleMinter:       MinterRole,
+	RoleOperator:     OperatorRole,
+	RolePauser:       PauserRole,
+}
+
+// RoleCount is the number of members of a role
+type RoleCount struct {
+	Name    string
+	Role    [32]byte
+	Members int
+}
+
+// InterfaceSupport is whether the contract supports an ERC165 interface
+type InterfaceSupport struct {
+	Interface
+	Supported bool
+}
+
+// Info is the extended information of a token contract. The fields which
+// are not defined by the token standard or not implemented by the contract
+// are nil.
+type Info struct {
+	Name        string
+	Symbol      string
+	Decimals    uint8
+	TotalSupply *big.Int
+	CodeSize    int
+
+	// NRC6|ERC20 only
+	Cap             *big.Int
+	Owner           *common.Address
+	MintingFinished *bool
+	TransferEnabled *bool
+
+	// NRC7|ERC721 only
+	Paused *bool
+
+	// Roles are counted by NRC7|ERC721 only, see RoleCounts for NRC6|ERC20
+	Roles []RoleCount
+	// RolesErr is the error of counting the role members, with Roles empty
+	RolesErr   error
+	Interfaces []InterfaceSupport
+}
+
+type callArg struct {
+	To   common.Address `json:"to"`
+	Data hexutil.Bytes  `json:"data"`
+}
+
+// infoBatch collects the json rpc requests of Info
+type infoBatch struct {
+	abi   abi.ABI
+	to    common.Address
+	elems []rpc.BatchElem
+}
+
+func (b *infoBatch) add(method string, result interface{}, args ...interface{}) int {
+	b.elems = append(b.elems, rpc.BatchElem{Method: method, Args: args, Result: result})
+	return len(b.elems) - 1
+}
+
+func (b *infoBatch) call(method string, args ...interface{}) int {
+	input, err := b.abi.Pack(method, args...)
+	if err != nil {
+		i := b.add("eth_call", new(hexutil.Bytes))
+		b.elems[i].Error = err
+		return i
+	}
+	return b.add("eth_call", new(hexutil.Bytes), callArg{To: b.to, Data: input}, "latest")
+}
+
+// unpack returns the single output of the eth_call at index i
+func (b *infoBatch) unpack(i int, method string) (interface{}, error) {
+	if b.elems[i].Error != nil {
+		return nil, b.elems[i].Error
+	}
+	data := *b.elems[i].Result.(*hexutil.Bytes)
+	if len(data) == 0 {
+		return nil, errors.New("no data returned")
+	}
+	out, err := b.abi.Unpack(method, data)
+	if err != nil {
+		return nil, err
+	}
+	if len(out) != 1 {
+		return nil, fmt.Errorf("unexpected outputs of %s", method)
+	}
+	return out[0], nil
+}
+
+// Info returns the extended information of the token. The requests are
+// sent in one batch by batcher, or one by one by the backend if batcher is
+// nil.
+func (t *Token) Info(ctx context.Context, batcher BatchCaller) (*Info, error) {
+	if batcher == nil {
+		batcher = &backendBatcher{backend: t.backend}
+	}
+	parsed, err := t.parsedABI()
+	if err != nil {
+		return nil, err
+	}
+
+	b := &infoBatch{abi: parsed, to: t.Address}
+	name := b.call("name")
+	symbol := b.call("symbol")
+	totalSupply := b.call("totalSupply")
+	code := b.add("eth_getCode", new(hexutil.Bytes), t.Address, "latest")
+
+	interfaces := fungibleInterfaces
+	var decimals, capSupply, owner, mintingFinished, transferEnabled, paused int
+	var roleCounts []int
+	if t.Kind == NonFungible {
+		interfaces = nonFungibleInterfaces
+		paused = b.call("paused")
+		for _, roleName := range t.RoleNames() {
+			roleCounts = append(roleCounts, b.call("getRoleMemberCount", roleIDs[roleName]))
+		}
+	} else {
+		decimals = b.call("decimals")
+		capSupply = b.call("cap")
+		owner = b.call("owner")
+		mintingFinished = b.call("mintingFinished")
+		transferEnabled = b.call("transferEnabled")
+	}
+	supports := make([]int, 0, len(interfaces))
+	for _, iface := range interfaces {
+		supports = append(supports, b.call("supportsInterface", iface.ID))
+	}
+
+	if err := batcher.BatchCallContext(ctx, b.elems); err != nil {
+		return nil, err
+	}
+
+	info := &Info{}
+	v, err := b.unpack(name, "name")
+	if err != nil {
+		return nil, fmt.Errorf("Name: Get name Error(%v)", err)
+	}
+	info.Name, _ = v.(string)
+	v, err = b.unpack(symbol, "symbol")
+	if err != nil {
+		return nil, fmt.Errorf("Symbol: Get symbol Error(%v)", err)
+	}
+	info.Symbol, _ = v.(string)
+	v, err = b.unpack(totalSupply, "totalSupply")
+	if err != nil {
+		return nil, fmt.Errorf("TotalSupply: Get totalSupply Error(%v)", err)
+	}
+	info.TotalSupply, _ = v.(*big.Int)
+	if b.elems[code].Error != nil {
+		return nil, fmt.Errorf("CodeAt: Get code Error(%v)", b.elems[code].Error)
+	}
+	info.CodeSize = len(*b.elems[code].Result.(*hexutil.Bytes))
+
+	unpackBool := func(i int, method string) *bool {
+		if v, err := b.unpack(i, method); err == nil {
+			if ok, isBool := v.(bool); isBool {
+				return &ok
+			}
+		}
+		return nil
+	}
+
+	if t.Kind == NonFungible {
+		info.Paused = unpackBool(paused, "paused")
+		for i, roleName := range t.RoleNames() {
+			v, err := b.unpack(roleCounts[i], "getRoleMemberCount")
+			if err != nil {
+				info.Roles, info.RolesErr = nil, fmt.Errorf("GetRoleMemberCount: Get %s members Error(%v)", roleName, err)
+				break
+			}
+			if count, ok := v.(*big.Int); ok {
+				info.Roles = append(info.Roles, RoleCount{Name: roleName, Role: roleIDs[roleName], Members: int(count.Int64())})
+			}
+		}
+	} else {
+		v, err = b.unpack(decimals, "decimals")
+		if err != nil {
+			return nil, fmt.Errorf("Decimals: Get decimals Error(%v)", err)
+		}
+		info.Decimals, _ = v.(uint8)
+		if v, err := b.unpack(capSupply, "cap"); err == nil {
+			info.Cap, _ = v.(*big.Int)
+		}
+		if v, err := b.unpack(owner, "owner"); err == nil {
+			if address, ok := v.(common.Address); ok {
+				info.Owner = &address
+			}
+		}
+		info.MintingFinished = unpackBool(mintingFinished, "mintingFinished")
+		info.TransferEnabled = unpackBool(transferEnabled, "transferEnabled")
+	}
+
+	for i, iface := range interfaces {
+		supported := unpackBool(supports[i], "supportsInterface")
+		info.Interfaces = append(info.Interfaces, InterfaceSupport{Interface: iface, Supported: supported != nil && *supported})
+	}
+
+	return info, nil
+}
+
+// RoleCounts counts the members of the roles of NRC6|ERC20, which are not
+// enumerable, by replaying the role logs from fromBlock to the latest block.
+// The roles granted before fromBlock are missed, so fromBlock should be no
+// later than the deploy block of the contract.
+func (t *Token) RoleCounts(ctx context.Context, fromBlock uint64) ([]RoleCount, error) {
+	logs, err := t.filterRoleLogs(ctx, fromBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	members := replayRoleLogs(logs)
+	counts := make([]RoleCount, 0, len(t.RoleNames()))
+	for _, roleName := range t.RoleNames() {
+		role := roleIDs[roleName]
+		counts = append(counts, RoleCount{Name: roleName, Role: role, Members: len(members[role])})
+	}
+
+	return counts, nil
+}
+
+var (
+	roleGrantedTopic = crypto.Keccak256Hash([]byte("RoleGranted(bytes32,address,address)"))
+	roleRevokedTopic = crypto.Keccak256Hash([]byte("RoleRevoked(bytes32,address,address)"))
+)
+
+// replayRoleLogs returns the members of the roles by replaying the
+// RoleGranted and RoleRevoked logs in order
+func replayRoleLogs(logs []types.Log) map[[32]byte]map[common.Address]bool {
+	members := make(map[[32]byte]map[common.Address]bool)
+	for _, log := range logs {
+		if log.Removed || len(log.Topics) < 3 {
+			continue
+		}
+		role := log.Topics[1]
+		account := common.BytesToAddress(log.Topics[2].Bytes())
+		if members[role] == nil {
+			members[role] = make(map[common.Address]bool)
+		}
+		switch log.Topics[0] {
+		case roleGrantedTopic:
+			members[role][account] = true
+		case roleRevokedTopic:
+			delete(members[role], account)
+		}
+	}
+	return members
+}
+
+// backendBatcher sends the requests of infoBatch one by one by the backend
+type backendBatcher struct {
+	backend Backend
+}
+
+func (bb *backendBatcher) BatchCallContext(ctx context.Context, elems []rpc.BatchElem) error {
+	for i := range elems {
+		elem := &elems[i]
+		if elem.Error != nil || len(elem.Args) == 0 {
+			continue
+		}
+		switch elem.Method {
+		case "eth_call":
+			arg := elem.Args[0].(callArg)
+			out, err := bb.backend.CallContract(ctx, ethereum.CallMsg{To: &arg.To, Data: arg.Data}, nil)
+			*elem.Result.(*hexutil.Bytes), elem.Error = out, err
+		case "eth_getCode":
+			out, err := bb.backend.CodeAt(ctx, elem.Args[0].(common.Address), nil)
+			*elem.Result.(*hexutil.Bytes), elem.Error = out, err
+		default:
+			elem.Error = fmt.Errorf("method %s not supported", elem.Method)
+		}
+	}
+	return nil
+}
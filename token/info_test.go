@@ -0,0 +1,35 @@
+package token
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestReplayRoleLogs(t *testing.T) {
+	alice := common.HexToAddress("0xeF0b04a14e62434a99C4aF28C6dAb52ba9B1C8F3")
+	bob := common.HexToAddress("0x6a038842f9E9010624eAeB5f30ec5004C05EE21D")
+	roleLog := func(topic common.Hash, role common.Hash, account common.Address) types.Log {
+		return types.Log{Topics: []common.Hash{topic, role, common.BytesToHash(account.Bytes()), {}}}
+	}
+
+	members := replayRoleLogs([]types.Log{
+		roleLog(roleGrantedTopic, MinterRole, alice),
+		roleLog(roleGrantedTopic, MinterRole, bob),
+		roleLog(roleRevokedTopic, MinterRole, alice),
+		roleLog(roleGrantedTopic, OperatorRole, alice),
+		roleLog(roleRevokedTopic, OperatorRole, alice),
+		roleLog(roleGrantedTopic, OperatorRole, alice),
+	})
+
+	if got := len(members[MinterRole]); got != 1 || !members[MinterRole][bob] {
+		t.Errorf("MINTER members: want only %s, got %v", bob.String(), members[MinterRole])
+	}
+	if got := len(members[OperatorRole]); got != 1 || !members[OperatorRole][alice] {
+		t.Errorf("OPERATOR members: want only %s, got %v", alice.String(), members[OperatorRole])
+	}
+	if got := len(members[PauserRole]); got != 0 {
+		t.Errorf("PAUSER members: want 0, got %d", got)
+	}
+}
@@ -0,0 +1,348 @@
+package token
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultIPFSGateway is the http gateway of ipfs:// URIs if not set
+const DefaultIPFSGateway = "https://ipfs.io/ipfs/"
+
+// maxMetadataSize is the max size of the metadata JSON fetched
+const maxMetadataSize = 1 << 20
+
+// ErrUnsupportedURI is returned when the token URI can not be resolved to
+// http(s) or data URI
+var ErrUnsupportedURI = errors.New("unsupported uri")
+
+// MetadataResolver fetches the ERC721 metadata JSON of token URIs, which
+// may be http(s)://, ipfs://, data: or relative URIs.
+type MetadataResolver struct {
+	// IPFSGateway is the http gateway of ipfs:// URIs, such as
+	// https://ipfs.io/ipfs/, DefaultIPFSGateway if empty
+	IPFSGateway string
+	// BaseURI is the base URI of the relative URIs, the relative URIs can
+	// not be resolved if empty
+	BaseURI string
+	// Client is the http client, a client with 30 seconds timeout if nil
+	Client *http.Client
+}
+
+// Resolve returns the http(s) URL or data URI to fetch the token URI
+func (r *MetadataResolver) Resolve(uri string) (string, error) {
+	uri = strings.TrimSpace(uri)
+	if uri == "" {
+		return "", fmt.Errorf("%w: empty uri", ErrUnsupportedURI)
+	}
+
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrUnsupportedURI, err)
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https", "data":
+		return uri, nil
+	case "ipfs":
+		return r.ipfsURL(uri)
+	case "":
+		if r.BaseURI == "" {
+			return "", fmt.Errorf("%w: relative uri %s without base uri", ErrUnsupportedURI, uri)
+		}
+		base, err := url.Parse(r.BaseURI)
+		if err != nil {
+			return "", fmt.Errorf("%w: base uri %s: %v", ErrUnsupportedURI, r.BaseURI, err)
+		}
+		if base.Scheme == "" {
+			return "", fmt.Errorf("%w: base uri %s is not absolute", ErrUnsupportedURI, r.BaseURI)
+		}
+		// the opaque ipfs://CID is not resolved by ResolveReference
+		if strings.EqualFold(base.Scheme, "ipfs") {
+			return r.ipfsURL(strings.TrimSuffix(r.BaseURI, "/") + "/" + strings.TrimPrefix(uri, "/"))
+		}
+		return r.Resolve(base.ResolveReference(u).String())
+	default:
+		return "", fmt.Errorf("%w: scheme %s of %s", ErrUnsupportedURI, u.Scheme, uri)
+	}
+}
+
+// ipfsURL returns the gateway URL of ipfs://CID/path or ipfs://ipfs/CID/path
+func (r *MetadataResolver) ipfsURL(uri string) (string, error) {
+	path := uri[len("ipfs://"):]
+	path = strings.TrimPrefix(path, "ipfs/")
+	if path == "" {
+		return "", fmt.Errorf("%w: ipfs uri %s without CID", ErrUnsupportedURI, uri)
+	}
+
+	gateway := r.IPFSGateway
+	if gateway == "" {
+		gateway = DefaultIPFSGateway
+	}
+	if !strings.HasSuffix(gateway, "/") {
+		gateway += "/"
+	}
+	return gateway + path, nil
+}
+
+// Fetch resolves the token URI and returns the metadata JSON
+func (r *MetadataResolver) Fetch(ctx context.Context, uri string) ([]byte, error) {
+	resolved, err := r.Resolve(uri)
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(strings.ToLower(resolved), "data:") {
+		return decodeDataURI(resolved)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, resolved, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", "application/json")
+
+	client := r.Client
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("get %s: %s", resolved, resp.Status)
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxMetadataSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > maxMetadataSize {
+		return nil, fmt.Errorf("get %s: metadata larger than %d bytes", resolved, maxMetadataSize)
+	}
+
+	return body, nil
+}
+
+// decodeDataURI returns the JSON of data:application/json[;base64],<data>
+func decodeDataURI(uri string) ([]byte, error) {
+	comma := strings.Index(uri, ",")
+	if comma < 0 {
+		return nil, fmt.Errorf("%w: data uri without comma", ErrUnsupportedURI)
+	}
+	meta, data := uri[len("data:"):comma], uri[comma+1:]
+
+	isBase64 := false
+	if strings.HasSuffix(strings.ToLower(meta), ";base64") {
+		isBase64 = true
+		meta = meta[:len(meta)-len(";base64")]
+	}
+	if meta != "" {
+		mediaType, _, err := mime.ParseMediaType(meta)
+		if err != nil {
+			return nil, fmt.Errorf("%w: data uri media type %s: %v", ErrUnsupportedURI, meta, err)
+		}
+		if mediaType != "application/json" && mediaType != "text/plain" {
+			return nil, fmt.Errorf("%w: data uri media type %s is not application/json", ErrUnsupportedURI, mediaType)
+		}
+	}
+
+	if isBase64 {
+		decoded, err := base64.StdEncoding.DecodeString(data)
+		if err != nil {
+			if decoded, err = base64.RawStdEncoding.DecodeString(data); err != nil {
+				return nil, fmt.Errorf("decode base64 data uri error: %v", err)
+			}
+		}
+		return decoded, nil
+	}
+
+	decoded, err := url.PathUnescape(data)
+	if err != nil {
+		return nil, fmt.Errorf("decode data uri error: %v", err)
+	}
+	return []byte(decoded), nil
+}
+
+// MetadataProblem is a problem of the metadata JSON against the ERC721
+// metadata JSON schema
+type MetadataProblem struct {
+	// Field is the JSON path of the problem such as attributes[0].value,
+	// empty for the whole document
+	Field   string
+	Message string
+}
+
+func (p MetadataProblem) String() string {
+	if p.Field == "" {
+		return p.Message
+	}
+	return p.Field + ": " + p.Message
+}
+
+// ValidateMetadata returns the problems of the metadata JSON against the
+// ERC721 metadata JSON schema, with the name, description and image
+// strings, and the optional attributes of trait_type and value
+func ValidateMetadata(data []byte) []MetadataProblem {
+	var problems []MetadataProblem
+	add := func(field, format string, a ...interface{}) {
+		problems = append(problems, MetadataProblem{Field: field, Message: fmt.Sprintf(format, a...)})
+	}
+
+	var doc map[string]json.RawMessage
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if err := decoder.Decode(&doc); err != nil {
+		add("", "invalid JSON object: %v", err)
+		return problems
+	}
+	if doc == nil {
+		add("", "invalid JSON object: null")
+		return problems
+	}
+
+	for _, field := range []string{"name", "description", "image"} {
+		raw, ok := doc[field]
+		if !ok {
+			add(field, "missing")
+			continue
+		}
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			add(field, "should be string, got %s", jsonType(raw))
+			continue
+		}
+		if strings.TrimSpace(s) == "" {
+			add(field, "empty")
+			continue
+		}
+		if field == "image" {
+			if u, err := url.Parse(s); err != nil || (u.Scheme == "" && !strings.HasPrefix(s, "/")) {
+				add(field, "should be a URI, got %q", s)
+			}
+		}
+	}
+
+	raw, ok := doc["attributes"]
+	if !ok {
+		return problems
+	}
+	var attributes []json.RawMessage
+	if err := json.Unmarshal(raw, &attributes); err != nil {
+		add("attributes", "should be array, got %s", jsonType(raw))
+		return problems
+	}
+	for i, raw := range attributes {
+		field := fmt.Sprintf("attributes[%d]", i)
+		var attribute map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &attribute); err != nil || attribute == nil {
+			add(field, "should be object, got %s", jsonType(raw))
+			continue
+		}
+		if traitType, ok := attribute["trait_type"]; ok {
+			var s string
+			if err := json.Unmarshal(traitType, &s); err != nil {
+				add(field+".trait_type", "should be string, got %s", jsonType(traitType))
+			}
+		}
+		value, ok := attribute["value"]
+		if !ok {
+			add(field+".value", "missing")
+			continue
+		}
+		switch jsonType(value) {
+		case "string", "number", "boolean":
+		default:
+			add(field+".value", "should be string, number or boolean, got %s", jsonType(value))
+		}
+	}
+
+	return problems
+}
+
+// jsonType returns the JSON type name of raw
+func jsonType(raw json.RawMessage) string {
+	s := bytes.TrimSpace(raw)
+	if len(s) == 0 {
+		return "nothing"
+	}
+	switch s[0] {
+	case '"':
+		return "string"
+	case '{':
+		return "object"
+	case '[':
+		return "array"
+	case 't', 'f':
+		return "boolean"
+	case 'n':
+		return "null"
+	default:
+		return "number"
+	}
+}
+
+// MetadataCheck is the result of checking the metadata of a tokenID
+type MetadataCheck struct {
+	TokenID  *big.Int
+	TokenURI string
+	// ResolvedURI is the http(s) URL or data URI fetched
+	ResolvedURI string
+	// Metadata is the metadata JSON fetched
+	Metadata []byte
+	// Err is the error of resolving or fetching the metadata
+	Err      error
+	Problems []MetadataProblem
+}
+
+// OK reports whether the metadata is fetched without problem
+func (c *MetadataCheck) OK() bool {
+	return c.Err == nil && len(c.Problems) == 0
+}
+
+// Check fetches and validates the metadata of the token URI
+func (r *MetadataResolver) Check(ctx context.Context, tokenID *big.Int, tokenURI string) *MetadataCheck {
+	check := &MetadataCheck{TokenID: tokenID, TokenURI: tokenURI}
+	check.ResolvedURI, check.Err = r.Resolve(tokenURI)
+	if check.Err != nil {
+		return check
+	}
+	check.Metadata, check.Err = r.Fetch(ctx, tokenURI)
+	if check.Err != nil {
+		return check
+	}
+	check.Problems = ValidateMetadata(check.Metadata)
+	return check
+}
+
+// CheckAll checks the metadata of the tokens with at most concurrency
+// fetches in parallel. progress is called with the number of tokens
+// checked if not nil. The checks are in the order of tokens.
+func (r *MetadataResolver) CheckAll(ctx context.Context, tokens []CollectionToken, concurrency int, progress func(checked int)) []*MetadataCheck {
+	checks := make([]*MetadataCheck, len(tokens))
+	var mu sync.Mutex
+	checked := 0
+	parallel(ctx, len(tokens), concurrency, func(ctx context.Context, i int) error {
+		checks[i] = r.Check(ctx, tokens[i].TokenID, tokens[i].TokenURI)
+		if progress != nil {
+			mu.Lock()
+			checked++
+			progress(checked)
+			mu.Unlock()
+		}
+		return nil
+	})
+	return checks
+}
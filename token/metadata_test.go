@@ -0,0 +1,111 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMetadataResolve(t *testing.T) {
+	r := &MetadataResolver{IPFSGateway: "https://gateway.example.com/ipfs", BaseURI: "https://example.com/tokens/"}
+
+	tests := []struct {
+		uri  string
+		want string
+	}{
+		{"https://example.com/1.json", "https://example.com/1.json"},
+		{"ipfs://QmCID/1.json", "https://gateway.example.com/ipfs/QmCID/1.json"},
+		{"ipfs://ipfs/QmCID/1.json", "https://gateway.example.com/ipfs/QmCID/1.json"},
+		{"1.json", "https://example.com/tokens/1.json"},
+		{"/1.json", "https://example.com/1.json"},
+		{"data:application/json;base64,e30=", "data:application/json;base64,e30="},
+	}
+	for _, tt := range tests {
+		got, err := r.Resolve(tt.uri)
+		if err != nil || got != tt.want {
+			t.Errorf("Resolve(%s): want %s, got %s, error %v", tt.uri, tt.want, got, err)
+		}
+	}
+
+	ipfsBase := &MetadataResolver{BaseURI: "ipfs://QmCID/"}
+	if got, err := ipfsBase.Resolve("1.json"); err != nil || got != DefaultIPFSGateway+"QmCID/1.json" {
+		t.Errorf("Resolve relative to ipfs base: got %s, error %v", got, err)
+	}
+
+	for _, uri := range []string{"", "ftp://example.com/1.json", "ipfs://", "1.json"} {
+		if _, err := (&MetadataResolver{}).Resolve(uri); !errors.Is(err, ErrUnsupportedURI) {
+			t.Errorf("Resolve(%s): want ErrUnsupportedURI, got %v", uri, err)
+		}
+	}
+}
+
+func TestMetadataFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if strings.HasSuffix(req.URL.Path, "/missing.json") {
+			http.NotFound(w, req)
+			return
+		}
+		fmt.Fprintf(w, `{"name":"%s"}`, req.URL.Path)
+	}))
+	defer server.Close()
+
+	r := &MetadataResolver{BaseURI: server.URL + "/tokens/"}
+	ctx := context.Background()
+
+	tests := []struct {
+		uri  string
+		want string
+	}{
+		{"1.json", `{"name":"/tokens/1.json"}`},
+		{server.URL + "/2.json", `{"name":"/2.json"}`},
+		{"data:application/json;base64,eyJuYW1lIjoiMyJ9", `{"name":"3"}`},
+		{"data:application/json,%7B%22name%22%3A%224%22%7D", `{"name":"4"}`},
+		{`data:application/json;charset=utf-8,{"name":"5"}`, `{"name":"5"}`},
+	}
+	for _, tt := range tests {
+		got, err := r.Fetch(ctx, tt.uri)
+		if err != nil || string(got) != tt.want {
+			t.Errorf("Fetch(%s): want %s, got %s, error %v", tt.uri, tt.want, got, err)
+		}
+	}
+
+	for _, uri := range []string{"missing.json", "data:image/png;base64,AAAA", "data:application/json;base64,!!"} {
+		if _, err := r.Fetch(ctx, uri); err == nil {
+			t.Errorf("Fetch(%s): want error", uri)
+		}
+	}
+}
+
+func TestValidateMetadata(t *testing.T) {
+	tests := []struct {
+		json string
+		want []string
+	}{
+		{`{"name":"A","description":"B","image":"ipfs://QmCID","attributes":[{"trait_type":"T","value":1},{"value":"v"}]}`, nil},
+		{`[]`, []string{"invalid JSON object"}},
+		{`{"name":1,"description":"","attributes":{}}`, []string{"name: should be string, got number", "description: empty", "image: missing", "attributes: should be array, got object"}},
+		{`{"name":"A","description":"B","image":"not a uri","attributes":[1,{"trait_type":2},{"value":null}]}`, []string{
+			`image: should be a URI, got "not a uri"`,
+			"attributes[0]: should be object, got number",
+			"attributes[1].trait_type: should be string, got number",
+			"attributes[1].value: missing",
+			"attributes[2].value: should be string, number or boolean, got null",
+		}},
+	}
+	for _, tt := range tests {
+		problems := ValidateMetadata([]byte(tt.json))
+		if len(problems) != len(tt.want) {
+			t.Errorf("ValidateMetadata(%s): want %v, got %v", tt.json, tt.want, problems)
+			continue
+		}
+		for i, p := range problems {
+			if !strings.HasPrefix(p.String(), tt.want[i]) {
+				t.Errorf("ValidateMetadata(%s): want %s, got %s", tt.json, tt.want[i], p)
+			}
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package token
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// MinterRole is the keccak256 hash of MINTER_ROLE
+var MinterRole = crypto.Keccak256Hash([]byte("MINTER_ROLE")) // 0x9f2df0fed2c77648de5860a4cc508cd0818c85b8b8a1ab4ceeef8d981c8956a6
+
+// IsMinter reports whether account has the minter role
+func (t *Token) IsMinter(ctx context.Context, account common.Address) (bool, error) {
+	if t.Kind == NonFungible {
+		return t.erc721.HasRole(callOpts(ctx), MinterRole, account)
+	}
+	return t.erc20.HasRole(callOpts(ctx), MinterRole, account)
+}
+
+// Mint mints a new tokenID for to, with tokenURI if not empty.
+// The minter role of opts.From and the paused flag are checked before signing.
+func (t *Token) Mint(opts *bind.TransactOpts, to common.Address, tokenURI string) (*types.Transaction, error) {
+	if err := t.requireNonFungible(); err != nil {
+		return nil, err
+	}
+	if err := t.CheckNotPaused(opts.Context); err != nil {
+		return nil, err
+	}
+
+	isMinter, err := t.IsMinter(opts.Context, opts.From)
+	if err != nil {
+		return nil, fmt.Errorf("check minter error(%v)", err)
+	}
+	if !isMinter {
+		return nil, fmt.Errorf("%w: the from address(%s) is not minter", ErrNotMinter, opts.From.String())
+	}
+
+	var tx *types.Transaction
+	if tokenURI == "" {
+		tx, err = t.erc721.Mint(opts, to)
+	} else {
+		tx, err = t.erc721.MintWithTokenURI(opts, to, tokenURI)
+	}
+
+	return tx, submitError(err)
+}
+
+// Cap returns the cap of total supply of a fungible token
+func (t *Token) Cap(ctx context.Context) (*big.Int, error) {
+	if err := t.requireFungible(); err != nil {
+		return nil, err
+	}
+	return t.erc20.Cap(callOpts(ctx))
+}
+
+// MintingFinished reports whether minting of a fungible token is finished
+func (t *Token) MintingFinished(ctx context.Context) (bool, error) {
+	if err := t.requireFungible(); err != nil {
+		return false, err
+	}
+	return t.erc20.MintingFinished(callOpts(ctx))
+}
+
+// MintAmount mints amount in base units of a fungible token for to.
+// The minter role of opts.From, the minting finished flag and the cap
+// headroom are checked before signing.
+func (t *Token) MintAmount(opts *bind.TransactOpts, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	if err := t.requireFungible(); err != nil {
+		return nil, err
+	}
+	if amount == nil || amount.Sign() <= 0 {
+		return nil, ErrInvalidAmount
+	}
+	callOpts := pendingCallOpts(opts.Context)
+
+	isMinter, err := t.IsMinter(opts.Context, opts.From)
+	if err != nil {
+		return nil, fmt.Errorf("check minter error(%v)", err)
+	}
+	if !isMinter {
+		return nil, fmt.Errorf("%w: the from address(%s) is not minter", ErrNotMinter, opts.From.String())
+	}
+
+	finished, err := t.erc20.MintingFinished(callOpts)
+	if err != nil {
+		return nil, fmt.Errorf("MintingFinished: Get minting finished Error(%v)", err)
+	}
+	if finished {
+		return nil, ErrMintingFinished
+	}
+
+	capacity, err := t.erc20.Cap(callOpts)
+	if err != nil {
+		return nil, fmt.Errorf("Cap: Get cap Error(%v)", err)
+	}
+	totalSupply, err := t.erc20.TotalSupply(callOpts)
+	if err != nil {
+		return nil, fmt.Errorf("TotalSupply: Get totalSupply Error(%v)", err)
+	}
+	// zero cap is no cap
+	if capacity.Sign() > 0 {
+		headroom := big.NewInt(0).Sub(capacity, totalSupply)
+		if headroom.Cmp(amount) < 0 {
+			decimals, err := t.erc20.Decimals(callOpts)
+			if err != nil {
+				return nil, fmt.Errorf("Decimals: Get decimals Error(%v)", err)
+			}
+			if headroom.Sign() < 0 {
+				headroom.SetInt64(0)
+			}
+			return nil, fmt.Errorf("%w: only %s can be minted under the cap(%s), less than the amount(%s)",
+				ErrCapExceeded, FormatAmount(headroom, decimals), FormatAmount(capacity, decimals), FormatAmount(amount, decimals))
+		}
+	}
+
+	tx, err := t.erc20.Mint(opts, to, amount)
+	return tx, submitError(err)
+}
+
+// FinishMinting ends minting of a fungible token permanently.
+// The ownership of opts.From and the minting finished flag are checked
+// before signing.
+func (t *Token) FinishMinting(opts *bind.TransactOpts) (*types.Transaction, error) {
+	if err := t.requireFungible(); err != nil {
+		return nil, err
+	}
+	if err := t.checkOwner(opts); err != nil {
+		return nil, err
+	}
+
+	finished, err := t.erc20.MintingFinished(pendingCallOpts(opts.Context))
+	if err != nil {
+		return nil, fmt.Errorf("MintingFinished: Get minting finished Error(%v)", err)
+	}
+	if finished {
+		return nil, ErrMintingFinished
+	}
+
+	tx, err := t.erc20.FinishMinting(opts)
+	return tx, submitError(err)
+}
+
+// MintedTokenIDs returns the tokenIDs minted in the receipt, decoded from
+// every Transfer log of the token from the zero address
+func (t *Token) MintedTokenIDs(receipt *Receipt) ([]*big.Int, error) {
+	if err := t.requireNonFungible(); err != nil {
+		return nil, err
+	}
+
+	transferTopic := crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	var tokenIDs []*big.Int
+	for _, log := range receipt.Logs {
+		if log.Address != t.Address || len(log.Topics) == 0 || log.Topics[0] != transferTopic {
+			continue
+		}
+		transfer, err := t.erc721.ParseTransfer(*log)
+		if err != nil {
+			return nil, fmt.Errorf("Unpack Log error: %v", err)
+		}
+		if transfer.From != (common.Address{}) {
+			continue
+		}
+		tokenIDs = append(tokenIDs, transfer.TokenId)
+	}
+
+	return tokenIDs, nil
+}
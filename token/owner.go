@@ -0,0 +1,78 @@
+package token
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Owner returns the owner of a fungible token contract
+func (t *Token) Owner(ctx context.Context) (common.Address, error) {
+	if err := t.requireFungible(); err != nil {
+		return common.Address{}, err
+	}
+	return t.erc20.Owner(callOpts(ctx))
+}
+
+// checkOwner checks that opts.From is the owner of the contract
+func (t *Token) checkOwner(opts *bind.TransactOpts) error {
+	owner, err := t.erc20.Owner(pendingCallOpts(opts.Context))
+	if err != nil {
+		return fmt.Errorf("Owner: Get owner Error(%v)", err)
+	}
+	if owner != opts.From {
+		return fmt.Errorf("%w: the owner is %s not %s", ErrNotOwner, owner.String(), opts.From.String())
+	}
+	return nil
+}
+
+// TransferOwnership transfers the ownership of a fungible token contract to
+// newOwner. The ownership of opts.From is checked before signing.
+func (t *Token) TransferOwnership(opts *bind.TransactOpts, newOwner common.Address) (*types.Transaction, error) {
+	if err := t.requireFungible(); err != nil {
+		return nil, err
+	}
+	if newOwner == (common.Address{}) {
+		return nil, ErrZeroAddress
+	}
+	if err := t.checkOwner(opts); err != nil {
+		return nil, err
+	}
+
+	tx, err := t.erc20.TransferOwnership(opts, newOwner)
+	return tx, submitError(err)
+}
+
+// RenounceOwnership leaves a fungible token contract without owner, which
+// can not be undone. The ownership of opts.From is checked before signing.
+func (t *Token) RenounceOwnership(opts *bind.TransactOpts) (*types.Transaction, error) {
+	if err := t.requireFungible(); err != nil {
+		return nil, err
+	}
+	if err := t.checkOwner(opts); err != nil {
+		return nil, err
+	}
+
+	tx, err := t.erc20.RenounceOwnership(opts)
+	return tx, submitError(err)
+}
+
+// IsFreshAccount reports whether account has no code and has never sent a
+// transaction, which may be a mistyped address
+func IsFreshAccount(ctx context.Context, backend Backend, account common.Address) (bool, error) {
+	code, err := backend.CodeAt(ctx, account, nil)
+	if err != nil {
+		return false, err
+	}
+	if len(code) > 0 {
+		return false, nil
+	}
+	nonce, err := backend.PendingNonceAt(ctx, account)
+	if err != nil {
+		return false, err
+	}
+	return nonce == 0, nil
+}
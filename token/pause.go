@@ -0,0 +1,85 @@
+package token
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Paused reports whether a non-fungible token is paused
+func (t *Token) Paused(ctx context.Context) (bool, error) {
+	if err := t.requireNonFungible(); err != nil {
+		return false, err
+	}
+	return t.erc721.Paused(callOpts(ctx))
+}
+
+// CheckNotPaused checks that a non-fungible token is not paused, so the
+// tokenIDs can be transferred, minted and burned. It is always nil for
+// fungible tokens.
+func (t *Token) CheckNotPaused(ctx context.Context) error {
+	if t.Kind != NonFungible {
+		return nil
+	}
+	paused, err := t.erc721.Paused(pendingCallOpts(ctx))
+	if err != nil {
+		return fmt.Errorf("Paused: Get paused Error(%v)", err)
+	}
+	if paused {
+		return fmt.Errorf("%w: the token is paused, unpause it first", ErrPaused)
+	}
+	return nil
+}
+
+// checkPauser checks that opts.From has the pauser role
+func (t *Token) checkPauser(opts *bind.TransactOpts) error {
+	isPauser, err := t.erc721.HasRole(pendingCallOpts(opts.Context), PauserRole, opts.From)
+	if err != nil {
+		return fmt.Errorf("HasRole: Check role Error(%v)", err)
+	}
+	if !isPauser {
+		return fmt.Errorf("%w: the from address(%s) has no PAUSER role", ErrNotPauser, opts.From.String())
+	}
+	return nil
+}
+
+// Pause pauses the transfer, mint and burn of a non-fungible token.
+// The pauser role of opts.From and the paused flag are checked before signing.
+func (t *Token) Pause(opts *bind.TransactOpts) (*types.Transaction, error) {
+	if err := t.requireNonFungible(); err != nil {
+		return nil, err
+	}
+	if err := t.checkPauser(opts); err != nil {
+		return nil, err
+	}
+	if err := t.CheckNotPaused(opts.Context); err != nil {
+		return nil, err
+	}
+
+	tx, err := t.erc721.Pause(opts)
+	return tx, submitError(err)
+}
+
+// Unpause unpauses a non-fungible token.
+// The pauser role of opts.From and the paused flag are checked before signing.
+func (t *Token) Unpause(opts *bind.TransactOpts) (*types.Transaction, error) {
+	if err := t.requireNonFungible(); err != nil {
+		return nil, err
+	}
+	if err := t.checkPauser(opts); err != nil {
+		return nil, err
+	}
+
+	paused, err := t.erc721.Paused(pendingCallOpts(opts.Context))
+	if err != nil {
+		return nil, fmt.Errorf("Paused: Get paused Error(%v)", err)
+	}
+	if !paused {
+		return nil, ErrNotPaused
+	}
+
+	tx, err := t.erc721.Unpause(opts)
+	return tx, submitError(err)
+}
@@ -0,0 +1,71 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Receipt is the result of a mined transaction
+type Receipt struct {
+	TxHash      common.Hash
+	BlockNumber *big.Int
+	Status      uint64
+	GasUsed     uint64
+	GasPrice    *big.Int
+	// GasFee is GasPrice x GasUsed
+	GasFee *big.Int
+	Logs   []*types.Log
+}
+
+// Succeeded reports whether the transaction status is successful
+func (r *Receipt) Succeeded() bool {
+	return r.Status == types.ReceiptStatusSuccessful
+}
+
+// WaitMined waits for tx to be mined and returns its receipt
+func WaitMined(ctx context.Context, backend bind.DeployBackend, tx *types.Transaction) (*Receipt, error) {
+	txr, err := bind.WaitMined(ctx, backend, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return newReceipt(tx, txr), nil
+}
+
+// WaitDeployed waits for the contract creation tx to be mined and checks
+// that the contract code is deployed
+func WaitDeployed(ctx context.Context, backend bind.DeployBackend, tx *types.Transaction) (*Receipt, error) {
+	if tx.To() != nil {
+		return nil, errors.New("tx is not contract creation")
+	}
+	txr, err := bind.WaitMined(ctx, backend, tx)
+	if err != nil {
+		return nil, err
+	}
+	receipt := newReceipt(tx, txr)
+	if txr.ContractAddress == (common.Address{}) {
+		return receipt, errors.New("zero address")
+	}
+	code, err := backend.CodeAt(ctx, txr.ContractAddress, nil)
+	if err == nil && len(code) == 0 {
+		err = bind.ErrNoCodeAfterDeploy
+	}
+	return receipt, err
+}
+
+func newReceipt(tx *types.Transaction, txr *types.Receipt) *Receipt {
+	return &Receipt{
+		TxHash:      txr.TxHash,
+		BlockNumber: txr.BlockNumber,
+		Status:      txr.Status,
+		GasUsed:     txr.GasUsed,
+		GasPrice:    tx.GasPrice(),
+		GasFee:      big.NewInt(0).Mul(tx.GasPrice(), big.NewInt(0).SetUint64(txr.GasUsed)),
+		Logs:        txr.Logs,
+	}
+}
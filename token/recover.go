@@ -0,0 +1,75 @@
+package token
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/newtonproject/tokencommander/contracts/ERC20"
+)
+
+// Stuck is the balance of a NRC6|ERC20 token held by the token contract itself
+type Stuck struct {
+	Token    common.Address
+	Symbol   string
+	Decimals uint8
+	Balance  *big.Int
+}
+
+// StuckBalance returns the balance of the NRC6|ERC20 token at tokenAddress
+// which is held by the token contract, with the symbol and decimals of
+// that token
+func (t *Token) StuckBalance(ctx context.Context, tokenAddress common.Address) (*Stuck, error) {
+	if err := t.requireFungible(); err != nil {
+		return nil, err
+	}
+
+	stuckToken, err := ERC20.NewBaseToken(tokenAddress, t.backend)
+	if err != nil {
+		return nil, err
+	}
+
+	stuck := &Stuck{Token: tokenAddress}
+	stuck.Balance, err = stuckToken.BalanceOf(callOpts(ctx), t.Address)
+	if err != nil {
+		return nil, fmt.Errorf("Balance: BalanceOf Error(%v)", err)
+	}
+	stuck.Decimals, err = stuckToken.Decimals(callOpts(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("Decimals: Get decimals Error(%v)", err)
+	}
+	// the symbol is optional for ERC20
+	stuck.Symbol, _ = stuckToken.Symbol(callOpts(ctx))
+
+	return stuck, nil
+}
+
+// RecoverERC20 sends amount of the NRC6|ERC20 token at tokenAddress held by
+// the token contract to the owner. The ownership of opts.From and the stuck
+// balance are checked before signing.
+func (t *Token) RecoverERC20(opts *bind.TransactOpts, tokenAddress common.Address, amount *big.Int) (*types.Transaction, error) {
+	if err := t.requireFungible(); err != nil {
+		return nil, err
+	}
+	if amount == nil || amount.Sign() <= 0 {
+		return nil, ErrInvalidAmount
+	}
+	if err := t.checkOwner(opts); err != nil {
+		return nil, err
+	}
+
+	stuck, err := t.StuckBalance(opts.Context, tokenAddress)
+	if err != nil {
+		return nil, err
+	}
+	if stuck.Balance.Cmp(amount) < 0 {
+		return nil, fmt.Errorf("%w: the contract holds %s of token %s, less than the amount(%s)", ErrInsufficientBalance,
+			FormatAmount(stuck.Balance, stuck.Decimals), tokenAddress.String(), FormatAmount(amount, stuck.Decimals))
+	}
+
+	tx, err := t.erc20.RecoverERC20(opts, tokenAddress, amount)
+	return tx, submitError(err)
+}
@@ -0,0 +1,87 @@
+package token
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/newtonproject/tokencommander/contracts/ERC20"
+	"github.com/newtonproject/tokencommander/contracts/ERC721"
+)
+
+// RevertReason returns the reason of a reverted call, decoded from the
+// error data returned by the node, or from the error message if the node
+// returns no data
+func RevertReason(err error) (string, bool) {
+	if err == nil {
+		return "", false
+	}
+
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if data, ok := dataErr.ErrorData().(string); ok {
+			if b, err := hexutil.Decode(data); err == nil {
+				if reason, err := abi.UnpackRevert(b); err == nil {
+					return reason, true
+				}
+			}
+		}
+	}
+
+	msg := err.Error()
+	if i := strings.Index(msg, "execution reverted"); i >= 0 {
+		reason := strings.TrimPrefix(msg[i+len("execution reverted"):], ":")
+		return strings.TrimSpace(reason), true
+	}
+
+	return "", false
+}
+
+// isMethodMissing reports whether the error of a call is of the method not
+// implemented by the contract, which reverts, or returns no data if the
+// contract has a fallback function
+func isMethodMissing(err error) bool {
+	if _, reverted := RevertReason(err); reverted {
+		return true
+	}
+	// the error of bind unpacking the empty data returned
+	return err != nil && strings.Contains(err.Error(), "attempting to unmarshall an empty string")
+}
+
+func (t *Token) parsedABI() (abi.ABI, error) {
+	if t.Kind == NonFungible {
+		return abi.JSON(strings.NewReader(ERC721.NRC7FullABI))
+	}
+	return abi.JSON(strings.NewReader(ERC20.BaseTokenABI))
+}
+
+// simulate calls method of the contract from opts.From without signing, and
+// returns ErrExecutionReverted with the revert reason if the call reverts
+func (t *Token) simulate(opts *bind.TransactOpts, method string, args ...interface{}) error {
+	parsed, err := t.parsedABI()
+	if err != nil {
+		return err
+	}
+	input, err := parsed.Pack(method, args...)
+	if err != nil {
+		return err
+	}
+
+	msg := ethereum.CallMsg{From: opts.From, To: &t.Address, Data: input}
+	if _, err := t.backend.CallContract(ensureContext(opts), msg, nil); err != nil {
+		if reason, ok := RevertReason(err); ok {
+			if reason == "" {
+				return fmt.Errorf("%w: %s", ErrExecutionReverted, method)
+			}
+			return fmt.Errorf("%w: %s", ErrExecutionReverted, reason)
+		}
+		return fmt.Errorf("simulate %s error: %v", method, err)
+	}
+
+	return nil
+}
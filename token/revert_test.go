@@ -0,0 +1,41 @@
+package token
+
+import (
+	"errors"
+	"testing"
+)
+
+type dataError struct {
+	msg  string
+	data interface{}
+}
+
+func (e *dataError) Error() string          { return e.msg }
+func (e *dataError) ErrorData() interface{} { return e.data }
+
+func TestRevertReason(t *testing.T) {
+	// Error(string) of "ERC1363: receiver returned wrong data"
+	data := "0x08c379a0" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000025" +
+		"455243313336333a2072656365697665722072657475726e65642077726f6e67" +
+		"2064617461000000000000000000000000000000000000000000000000000000"
+
+	tests := []struct {
+		err    error
+		reason string
+		ok     bool
+	}{
+		{nil, "", false},
+		{errors.New("connection refused"), "", false},
+		{&dataError{"execution reverted", data}, "ERC1363: receiver returned wrong data", true},
+		{&dataError{"execution reverted", "0x"}, "", true},
+		{errors.New("execution reverted: ERC1363: transfer to non contract address"), "ERC1363: transfer to non contract address", true},
+	}
+	for _, tt := range tests {
+		reason, ok := RevertReason(tt.err)
+		if ok != tt.ok || reason != tt.reason {
+			t.Errorf("RevertReason(%v): want (%q, %v), got (%q, %v)", tt.err, tt.reason, tt.ok, reason, ok)
+		}
+	}
+}
@@ -0,0 +1,276 @@
+package token
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// The role names resolved by RoleID
+const (
+	RoleDefaultAdmin = "DEFAULT_ADMIN"
+	RoleMinter       = "MINTER"
+	RoleOperator     = "OPERATOR"
+	RolePauser       = "PAUSER"
+)
+
+// PauserRole is the keccak256 hash of PAUSER_ROLE of NRC7|ERC721
+var PauserRole = crypto.Keccak256Hash([]byte("PAUSER_ROLE"))
+
+// RoleNames returns the names of the roles defined by the contract
+func (t *Token) RoleNames() []string {
+	if t.Kind == NonFungible {
+		return []string{RoleDefaultAdmin, RoleMinter, RolePauser}
+	}
+	return []string{RoleDefaultAdmin, RoleMinter, RoleOperator}
+}
+
+// RoleID resolves the role name, such as MINTER or MINTER_ROLE, through the
+// role getter of the contract. A role of 32 bytes hex is returned as is.
+func (t *Token) RoleID(ctx context.Context, name string) ([32]byte, error) {
+	if strings.HasPrefix(name, "0x") {
+		b, err := hexutil.Decode(name)
+		if err != nil || len(b) != 32 {
+			return [32]byte{}, fmt.Errorf("%w: %s", ErrUnknownRole, name)
+		}
+		return common.BytesToHash(b), nil
+	}
+
+	opts := callOpts(ctx)
+	switch normalizeRoleName(name) {
+	case RoleDefaultAdmin:
+		if t.Kind == NonFungible {
+			return t.erc721.DEFAULTADMINROLE(opts)
+		}
+		return t.erc20.DEFAULTADMINROLE(opts)
+	case RoleMinter:
+		if t.Kind == NonFungible {
+			return t.erc721.MINTERROLE(opts)
+		}
+		return t.erc20.MINTERROLE(opts)
+	case RoleOperator:
+		if t.Kind == Fungible {
+			return t.erc20.OPERATORROLE(opts)
+		}
+	case RolePauser:
+		if t.Kind == NonFungible {
+			return t.erc721.PAUSERROLE(opts)
+		}
+	}
+
+	return [32]byte{}, fmt.Errorf("%w: %s, only support %s", ErrUnknownRole, name, strings.Join(t.RoleNames(), "|"))
+}
+
+// RoleName returns the name of role, or the hex of role if unknown
+func (t *Token) RoleName(ctx context.Context, role [32]byte) string {
+	for _, name := range t.RoleNames() {
+		if id, err := t.RoleID(ctx, name); err == nil && id == role {
+			return name
+		}
+	}
+	return hexutil.Encode(role[:])
+}
+
+func normalizeRoleName(name string) string {
+	name = strings.TrimSuffix(strings.ToUpper(name), "_ROLE")
+	if name == "ADMIN" {
+		return RoleDefaultAdmin
+	}
+	return name
+}
+
+// HasRole reports whether account has role
+func (t *Token) HasRole(ctx context.Context, role [32]byte, account common.Address) (bool, error) {
+	if t.Kind == NonFungible {
+		return t.erc721.HasRole(callOpts(ctx), role, account)
+	}
+	return t.erc20.HasRole(callOpts(ctx), role, account)
+}
+
+// RoleAdmin returns the admin role of role
+func (t *Token) RoleAdmin(ctx context.Context, role [32]byte) ([32]byte, error) {
+	if t.Kind == NonFungible {
+		return t.erc721.GetRoleAdmin(callOpts(ctx), role)
+	}
+	return t.erc20.GetRoleAdmin(callOpts(ctx), role)
+}
+
+// RoleMembers returns the accounts which have role.
+// NRC7|ERC721 enumerates the members by GetRoleMember, while NRC6|ERC20
+// which is not enumerable replays the RoleGranted and RoleRevoked events
+// and checks the members by HasRole.
+func (t *Token) RoleMembers(ctx context.Context, role [32]byte) ([]common.Address, error) {
+	if t.Kind == NonFungible {
+		count, err := t.erc721.GetRoleMemberCount(callOpts(ctx), role)
+		if err != nil {
+			return nil, err
+		}
+		members := make([]common.Address, 0, count.Uint64())
+		for i := uint64(0); i < count.Uint64(); i++ {
+			member, err := t.erc721.GetRoleMember(callOpts(ctx), role, big.NewInt(0).SetUint64(i))
+			if err != nil {
+				return nil, err
+			}
+			members = append(members, member)
+		}
+		return members, nil
+	}
+
+	logs, err := t.filterRoleLogs(ctx, 0, role)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []common.Address
+	seen := make(map[common.Address]bool)
+	for _, log := range logs {
+		if log.Removed || len(log.Topics) < 3 || log.Topics[0] != roleGrantedTopic {
+			continue
+		}
+		account := common.BytesToAddress(log.Topics[2].Bytes())
+		if !seen[account] {
+			seen[account] = true
+			candidates = append(candidates, account)
+		}
+	}
+
+	// the revoked accounts are filtered out by HasRole
+	members := make([]common.Address, 0, len(candidates))
+	for _, account := range candidates {
+		has, err := t.erc20.HasRole(callOpts(ctx), role, account)
+		if err != nil {
+			return nil, err
+		}
+		if has {
+			members = append(members, account)
+		}
+	}
+
+	return members, nil
+}
+
+// filterRoleLogs returns the RoleGranted and RoleRevoked logs of roles, or
+// of all the roles if none, from block start to the latest block in order.
+// The whole range is filtered by one request at first, and in the chunks
+// of filterChunks if the provider limits the logs of a request.
+func (t *Token) filterRoleLogs(ctx context.Context, start uint64, roles ...[32]byte) ([]types.Log, error) {
+	latest, err := t.latestBlock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if start > latest {
+		return nil, nil
+	}
+
+	topics := [][]common.Hash{{roleGrantedTopic, roleRevokedTopic}}
+	if len(roles) > 0 {
+		roleTopics := make([]common.Hash, 0, len(roles))
+		for _, role := range roles {
+			roleTopics = append(roleTopics, role)
+		}
+		topics = append(topics, roleTopics)
+	}
+
+	var logs []types.Log
+	err = filterChunks(start, latest, latest-start+1, func(start, end uint64) (int, error) {
+		found, err := t.backend.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: big.NewInt(0).SetUint64(start),
+			ToBlock:   big.NewInt(0).SetUint64(end),
+			Addresses: []common.Address{t.Address},
+			Topics:    topics,
+		})
+		if err != nil {
+			return 0, err
+		}
+		logs = append(logs, found...)
+		return len(found), nil
+	}, nil)
+	if err != nil {
+		return logs, fmt.Errorf("FilterRoleLogs: %v", err)
+	}
+
+	return logs, nil
+}
+
+// checkRoleAdmin checks that opts.From has the admin role of role
+func (t *Token) checkRoleAdmin(opts *bind.TransactOpts, role [32]byte) error {
+	admin, err := t.RoleAdmin(opts.Context, role)
+	if err != nil {
+		return fmt.Errorf("GetRoleAdmin: Get role admin Error(%v)", err)
+	}
+	isAdmin, err := t.HasRole(opts.Context, admin, opts.From)
+	if err != nil {
+		return fmt.Errorf("HasRole: Check role Error(%v)", err)
+	}
+	if !isAdmin {
+		return fmt.Errorf("%w: the from address(%s) has no admin role %s of %s", ErrNotRoleAdmin,
+			opts.From.String(), t.RoleName(opts.Context, admin), t.RoleName(opts.Context, role))
+	}
+	return nil
+}
+
+// GrantRole grants role to account.
+// The admin role of opts.From is checked before signing.
+func (t *Token) GrantRole(opts *bind.TransactOpts, role [32]byte, account common.Address) (*types.Transaction, error) {
+	if err := t.checkRoleAdmin(opts, role); err != nil {
+		return nil, err
+	}
+
+	var tx *types.Transaction
+	var err error
+	if t.Kind == NonFungible {
+		tx, err = t.erc721.GrantRole(opts, role, account)
+	} else {
+		tx, err = t.erc20.GrantRole(opts, role, account)
+	}
+
+	return tx, submitError(err)
+}
+
+// RevokeRole revokes role from account.
+// The admin role of opts.From is checked before signing.
+func (t *Token) RevokeRole(opts *bind.TransactOpts, role [32]byte, account common.Address) (*types.Transaction, error) {
+	if err := t.checkRoleAdmin(opts, role); err != nil {
+		return nil, err
+	}
+
+	var tx *types.Transaction
+	var err error
+	if t.Kind == NonFungible {
+		tx, err = t.erc721.RevokeRole(opts, role, account)
+	} else {
+		tx, err = t.erc20.RevokeRole(opts, role, account)
+	}
+
+	return tx, submitError(err)
+}
+
+// RenounceRole renounces role of opts.From.
+// The role of opts.From is checked before signing.
+func (t *Token) RenounceRole(opts *bind.TransactOpts, role [32]byte) (*types.Transaction, error) {
+	has, err := t.HasRole(opts.Context, role, opts.From)
+	if err != nil {
+		return nil, fmt.Errorf("HasRole: Check role Error(%v)", err)
+	}
+	if !has {
+		return nil, fmt.Errorf("%w: the from address(%s) has no role %s",
+			ErrNoRole, opts.From.String(), t.RoleName(opts.Context, role))
+	}
+
+	var tx *types.Transaction
+	if t.Kind == NonFungible {
+		tx, err = t.erc721.RenounceRole(opts, role, opts.From)
+	} else {
+		tx, err = t.erc20.RenounceRole(opts, role, opts.From)
+	}
+
+	return tx, submitError(err)
+}
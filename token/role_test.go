@@ -0,0 +1,204 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/newtonproject/tokencommander/contracts/ERC20"
+)
+
+// fakeRoleBackend answers the role logs and hasRole of a fungible token,
+// and fails the filter requests of more than limit blocks
+type fakeRoleBackend struct {
+	Backend
+	abi      abi.ABI
+	logs     []types.Log
+	members  map[common.Address]bool
+	latest   uint64
+	limit    uint64
+	requests int
+}
+
+func (b *fakeRoleBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return &types.Header{Number: big.NewInt(0).SetUint64(b.latest)}, nil
+}
+
+func (b *fakeRoleBackend) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	b.requests++
+	if q.ToBlock.Uint64()-q.FromBlock.Uint64()+1 > b.limit {
+		return nil, errors.New("query returned more than 10000 results")
+	}
+
+	var logs []types.Log
+	for _, log := range b.logs {
+		if log.BlockNumber < q.FromBlock.Uint64() || log.BlockNumber > q.ToBlock.Uint64() {
+			continue
+		}
+		match := true
+		for i, topics := range q.Topics {
+			if len(topics) > 0 && !containsHash(topics, log.Topics[i]) {
+				match = false
+			}
+		}
+		if match {
+			logs = append(logs, log)
+		}
+	}
+	return logs, nil
+}
+
+func containsHash(hashes []common.Hash, hash common.Hash) bool {
+	for _, h := range hashes {
+		if h == hash {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *fakeRoleBackend) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return []byte{0x01}, nil
+}
+
+func (b *fakeRoleBackend) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	method, err := b.abi.MethodById(call.Data[:4])
+	if err != nil {
+		return nil, err
+	}
+	args, err := method.Inputs.Unpack(call.Data[4:])
+	if err != nil {
+		return nil, err
+	}
+	switch method.Name {
+	case "hasRole":
+		return method.Outputs.Pack(b.members[args[1].(common.Address)])
+	case "name", "symbol":
+		return method.Outputs.Pack("MyToken")
+	case "totalSupply":
+		return method.Outputs.Pack(big.NewInt(1000))
+	case "decimals":
+		return method.Outputs.Pack(uint8(18))
+	}
+	return nil, fmt.Errorf("unexpected call of %s", method.Name)
+}
+
+func TestRoleMembers(t *testing.T) {
+	parsed, err := abi.JSON(strings.NewReader(ERC20.BaseTokenABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	alice := common.HexToAddress("0xeF0b04a14e62434a99C4aF28C6dAb52ba9B1C8F3")
+	bob := common.HexToAddress("0x6a038842f9E9010624eAeB5f30ec5004C05EE21D")
+	carol := common.HexToAddress("0xDC8F76075Db000Fa70fdA3AA2c95d63F22A10a67")
+	roleLog := func(block uint64, topic common.Hash, role common.Hash, account common.Address) types.Log {
+		return types.Log{BlockNumber: block, Topics: []common.Hash{topic, role, common.BytesToHash(account.Bytes()), {}}}
+	}
+
+	backend := &fakeRoleBackend{
+		abi: parsed,
+		logs: []types.Log{
+			roleLog(3, roleGrantedTopic, MinterRole, alice),
+			roleLog(700, roleGrantedTopic, MinterRole, bob),
+			roleLog(800, roleGrantedTopic, OperatorRole, carol),
+			roleLog(900, roleRevokedTopic, MinterRole, alice),
+			roleLog(999, roleGrantedTopic, MinterRole, carol),
+		},
+		members: map[common.Address]bool{bob: true, carol: true},
+		latest:  1000,
+		limit:   1000000,
+	}
+	tok, err := New(common.Address{}, Fungible, backend)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []common.Address{bob, carol}
+	for _, limit := range []uint64{1000000, 100} {
+		backend.limit, backend.requests = limit, 0
+		members, err := tok.RoleMembers(context.Background(), MinterRole)
+		if err != nil {
+			t.Fatalf("RoleMembers(limit %d): %v", limit, err)
+		}
+		if !reflect.DeepEqual(members, want) {
+			t.Errorf("RoleMembers(limit %d): want %v, got %v", limit, want, members)
+		}
+		if limit > backend.latest && backend.requests != 1 {
+			t.Errorf("RoleMembers(limit %d): want the whole range by 1 request, got %d requests", limit, backend.requests)
+		}
+	}
+
+	backend.limit = 0
+	if _, err := tok.RoleMembers(context.Background(), MinterRole); err == nil {
+		t.Error("RoleMembers: want error when every request fails")
+	}
+}
+
+func TestInfoRoles(t *testing.T) {
+	parsed, err := abi.JSON(strings.NewReader(ERC20.BaseTokenABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	alice := common.HexToAddress("0xeF0b04a14e62434a99C4aF28C6dAb52ba9B1C8F3")
+	bob := common.HexToAddress("0x6a038842f9E9010624eAeB5f30ec5004C05EE21D")
+	roleLog := func(block uint64, topic common.Hash, role common.Hash, account common.Address) types.Log {
+		return types.Log{BlockNumber: block, Topics: []common.Hash{topic, role, common.BytesToHash(account.Bytes()), {}}}
+	}
+
+	backend := &fakeRoleBackend{
+		abi: parsed,
+		logs: []types.Log{
+			roleLog(3, roleGrantedTopic, MinterRole, alice),
+			roleLog(700, roleGrantedTopic, MinterRole, bob),
+			roleLog(900, roleRevokedTopic, MinterRole, alice),
+		},
+		latest: 1000,
+		limit:  100,
+	}
+	tok, err := New(common.Address{}, Fungible, backend)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := tok.Info(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if backend.requests != 0 || len(info.Roles) != 0 || info.RolesErr != nil {
+		t.Errorf("Info: want no role logs filtered, got %d requests, %v, %v", backend.requests, info.Roles, info.RolesErr)
+	}
+
+	minters := func(counts []RoleCount) int {
+		for _, r := range counts {
+			if r.Name == RoleMinter {
+				return r.Members
+			}
+		}
+		return -1
+	}
+	for fromBlock, want := range map[uint64]int{0: 1, 800: 0, 2000: 0} {
+		counts, err := tok.RoleCounts(context.Background(), fromBlock)
+		if err != nil {
+			t.Fatalf("RoleCounts(from %d): %v", fromBlock, err)
+		}
+		if len(counts) != len(tok.RoleNames()) {
+			t.Errorf("RoleCounts(from %d): want %d roles, got %v", fromBlock, len(tok.RoleNames()), counts)
+		}
+		if got := minters(counts); got != want {
+			t.Errorf("RoleCounts(from %d): want %d MINTER members, got %d", fromBlock, want, got)
+		}
+	}
+
+	backend.limit = 0
+	if _, err := tok.RoleCounts(context.Background(), 0); err == nil {
+		t.Error("RoleCounts: want error when every request fails")
+	}
+}
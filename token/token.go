@@ -0,0 +1,250 @@
+// Package token is the Go SDK behind the TokenCommander CLI. It wraps the
+// generated NRC6|ERC20 and NRC7|ERC721 bindings with typed functions that
+// return results and errors instead of printing them.
+package token
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/newtonproject/tokencommander/contracts/ERC20"
+	"github.com/newtonproject/tokencommander/contracts/ERC721"
+)
+
+// Kind is the token standard implemented by a contract
+type Kind int
+
+const (
+	// Fungible is the NRC6|ERC20 token standard
+	Fungible Kind = iota
+	// NonFungible is the NRC7|ERC721 token standard
+	NonFungible
+)
+
+func (k Kind) String() string {
+	if k == NonFungible {
+		return "ERC721"
+	}
+	return "ERC20"
+}
+
+// Backend is the chain backend the token functions need to call,
+// transact and wait for receipts
+type Backend interface {
+	bind.ContractBackend
+	bind.DeployBackend
+}
+
+// headerReader is the backend which can get the block headers, such as
+// ethclient
+type headerReader interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// Token is a deployed NRC6|ERC20 or NRC7|ERC721 contract
+type Token struct {
+	Address common.Address
+	Kind    Kind
+
+	backend Backend
+	erc20   *ERC20.BaseToken
+	erc721  *ERC721.NRC7Full
+}
+
+// New binds the contract at address as a token of the given kind
+func New(address common.Address, kind Kind, backend Backend) (*Token, error) {
+	if backend == nil {
+		return nil, errors.New("backend is nil")
+	}
+
+	t := &Token{Address: address, Kind: kind, backend: backend}
+
+	var err error
+	if kind == NonFungible {
+		t.erc721, err = ERC721.NewNRC7Full(address, backend)
+	} else {
+		t.erc20, err = ERC20.NewBaseToken(address, backend)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("NewSimpleToken Error(%v)", err)
+	}
+
+	return t, nil
+}
+
+// ERC20 returns the NRC6|ERC20 binding, nil if the token is non-fungible
+func (t *Token) ERC20() *ERC20.BaseToken {
+	return t.erc20
+}
+
+// ERC721 returns the NRC7|ERC721 binding, nil if the token is fungible
+func (t *Token) ERC721() *ERC721.NRC7Full {
+	return t.erc721
+}
+
+// Contract returns the underlying binding of the token
+func (t *Token) Contract() interface{} {
+	if t.Kind == NonFungible {
+		return t.erc721
+	}
+	return t.erc20
+}
+
+// Backend returns the backend the token is bound with
+func (t *Token) Backend() Backend {
+	return t.backend
+}
+
+func (t *Token) requireFungible() error {
+	if t.erc20 == nil {
+		return ErrOnlyFungible
+	}
+	return nil
+}
+
+func (t *Token) requireNonFungible() error {
+	if t.erc721 == nil {
+		return ErrOnlyNonFungible
+	}
+	return nil
+}
+
+// ensureContext returns the context of opts, or the background context if
+// it is nil
+func ensureContext(opts *bind.TransactOpts) context.Context {
+	if opts.Context == nil {
+		return context.Background()
+	}
+	return opts.Context
+}
+
+func callOpts(ctx context.Context) *bind.CallOpts {
+	return &bind.CallOpts{Context: ctx}
+}
+
+func pendingCallOpts(ctx context.Context) *bind.CallOpts {
+	return &bind.CallOpts{Pending: true, Context: ctx}
+}
+
+// Name returns the name of the token
+func (t *Token) Name(ctx context.Context) (string, error) {
+	if t.Kind == NonFungible {
+		return t.erc721.Name(callOpts(ctx))
+	}
+	return t.erc20.Name(callOpts(ctx))
+}
+
+// Symbol returns the symbol of the token
+func (t *Token) Symbol(ctx context.Context) (string, error) {
+	if t.Kind == NonFungible {
+		return t.erc721.Symbol(callOpts(ctx))
+	}
+	return t.erc20.Symbol(callOpts(ctx))
+}
+
+// Decimals returns the decimals of a fungible token, or 0 for non-fungible
+func (t *Token) Decimals(ctx context.Context) (uint8, error) {
+	if t.Kind == NonFungible {
+		return 0, nil
+	}
+	return t.erc20.Decimals(callOpts(ctx))
+}
+
+// TotalSupply returns the total supply of the token
+func (t *Token) TotalSupply(ctx context.Context) (*big.Int, error) {
+	if t.Kind == NonFungible {
+		return t.erc721.TotalSupply(callOpts(ctx))
+	}
+	return t.erc20.TotalSupply(callOpts(ctx))
+}
+
+// BalanceOf returns the balance of owner in base units, or the number of
+// tokenIDs owned for non-fungible tokens
+func (t *Token) BalanceOf(ctx context.Context, owner common.Address) (*big.Int, error) {
+	if t.Kind == NonFungible {
+		return t.erc721.BalanceOf(callOpts(ctx), owner)
+	}
+	return t.erc20.BalanceOf(callOpts(ctx), owner)
+}
+
+// OwnerOf returns the owner of a non-fungible tokenID
+func (t *Token) OwnerOf(ctx context.Context, tokenID *big.Int) (common.Address, error) {
+	if err := t.requireNonFungible(); err != nil {
+		return common.Address{}, err
+	}
+	return t.erc721.OwnerOf(callOpts(ctx), tokenID)
+}
+
+// TokensOfOwner returns the tokenIDs owned by owner by the tokensOfOwner
+// view of the contract in one call, or by walking TokenOfOwnerByIndex
+// concurrently in pages for the contracts without tokensOfOwner. The calls
+// are at the same block so the tokenIDs are consistent.
+func (t *Token) TokensOfOwner(ctx context.Context, owner common.Address) ([]*big.Int, error) {
+	if err := t.requireNonFungible(); err != nil {
+		return nil, err
+	}
+
+	opts := &bind.CallOpts{Context: ctx}
+	// pin the latest block if the backend can tell, such as ethclient
+	if reader, ok := t.backend.(headerReader); ok {
+		header, err := reader.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("HeaderByNumber: Get latest block Error(%v)", err)
+		}
+		opts.BlockNumber = header.Number
+	}
+
+	tokens, err := t.erc721.TokensOfOwner(opts, owner)
+	if err == nil {
+		return tokens, nil
+	}
+	// walk the index only if tokensOfOwner is not implemented, not for the
+	// errors such as of the network which fail the walk too
+	if !isMethodMissing(err) {
+		return nil, fmt.Errorf("TokensOfOwner: Get tokens of owner Error(%v)", err)
+	}
+
+	return t.tokensOfOwnerByIndex(opts, owner, DefaultConcurrency)
+}
+
+// tokensOfOwnerPageSize is the number of tokenIDs walked in order by one
+// worker of tokensOfOwnerByIndex
+const tokensOfOwnerPageSize = 50
+
+// tokensOfOwnerByIndex returns the tokenIDs owned by owner by walking
+// TokenOfOwnerByIndex in pages, with at most concurrency pages in parallel
+func (t *Token) tokensOfOwnerByIndex(opts *bind.CallOpts, owner common.Address, concurrency int) ([]*big.Int, error) {
+	balance, err := t.erc721.BalanceOf(opts, owner)
+	if err != nil {
+		return nil, fmt.Errorf("BalanceOf: Get balance Error(%v)", err)
+	}
+	if !balance.IsUint64() {
+		return nil, fmt.Errorf("BalanceOf: balance %s overflow", balance.String())
+	}
+	n := balance.Uint64()
+
+	tokens := make([]*big.Int, n)
+	pages := int((n + tokensOfOwnerPageSize - 1) / tokensOfOwnerPageSize)
+	_, err = parallel(opts.Context, pages, concurrency, func(ctx context.Context, page int) error {
+		pageOpts := &bind.CallOpts{Context: ctx, BlockNumber: opts.BlockNumber}
+		start := uint64(page) * tokensOfOwnerPageSize
+		for i := start; i < n && i < start+tokensOfOwnerPageSize; i++ {
+			tokenID, err := t.erc721.TokenOfOwnerByIndex(pageOpts, owner, big.NewInt(0).SetUint64(i))
+			if err != nil {
+				return fmt.Errorf("TokenOfOwnerByIndex: Get tokenID of index %d Error(%v)", i, err)
+			}
+			tokens[i] = tokenID
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
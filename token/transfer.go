@@ -0,0 +1,213 @@
+package token
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Transfer pays amount in base units of a fungible token, or transfers the
+// tokenID amount of a non-fungible token, from opts.From to to.
+// The balance or ownership of opts.From is checked before signing.
+func (t *Token) Transfer(opts *bind.TransactOpts, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	if err := t.CheckTransfer(opts, amount); err != nil {
+		return nil, err
+	}
+
+	var tx *types.Transaction
+	var err error
+	if t.Kind == NonFungible {
+		tx, err = t.erc721.TransferFrom(opts, opts.From, to, amount)
+	} else {
+		tx, err = t.erc20.Transfer(opts, to, amount)
+	}
+
+	return tx, submitError(err)
+}
+
+// CheckTransfer checks that opts.From holds amount of a fungible token, or
+// owns the tokenID amount of a non-fungible token which is not paused
+func (t *Token) CheckTransfer(opts *bind.TransactOpts, amount *big.Int) error {
+	if amount == nil || amount.Sign() < 0 {
+		return ErrInvalidAmount
+	}
+	from := opts.From
+	callOpts := pendingCallOpts(opts.Context)
+
+	if t.Kind == NonFungible {
+		if err := t.CheckNotPaused(opts.Context); err != nil {
+			return err
+		}
+		tokenOwner, err := t.erc721.OwnerOf(callOpts, amount)
+		if err != nil {
+			return fmt.Errorf("OwnerOf: OwnerOf Error(%v)", err)
+		}
+		if tokenOwner != from {
+			return fmt.Errorf("%w: the owner of tokenID(%s) is %s not %s",
+				ErrNotTokenOwner, amount.String(), tokenOwner.String(), from.String())
+		}
+		return nil
+	}
+
+	if err := t.CheckTransferEnabled(opts.Context, from); err != nil {
+		return err
+	}
+
+	return t.checkBalance(callOpts, from, amount)
+}
+
+// checkBalance checks that holder holds amount of a fungible token
+func (t *Token) checkBalance(callOpts *bind.CallOpts, holder common.Address, amount *big.Int) error {
+	balance, err := t.erc20.BalanceOf(callOpts, holder)
+	if err != nil {
+		return fmt.Errorf("Balance: BalanceOf Error(%v)", err)
+	}
+	if balance.Cmp(amount) < 0 {
+		decimals, err := t.erc20.Decimals(callOpts)
+		if err != nil {
+			return fmt.Errorf("Decimals: Get decimals Error(%v)", err)
+		}
+		return fmt.Errorf("%w: there is not enough balance(%s) to pay the amount(%s) of current transactions",
+			ErrInsufficientBalance, FormatAmount(balance, decimals), FormatAmount(amount, decimals))
+	}
+
+	return nil
+}
+
+// TransferFrom pays amount in base units of a fungible token, or transfers
+// the tokenID amount of a non-fungible token, from owner to to on behalf of
+// opts.From. The allowance or approval of opts.From and the balance or
+// ownership of owner are checked before signing.
+func (t *Token) TransferFrom(opts *bind.TransactOpts, owner, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	if err := t.CheckTransferFrom(opts, owner, amount); err != nil {
+		return nil, err
+	}
+
+	var tx *types.Transaction
+	var err error
+	if t.Kind == NonFungible {
+		tx, err = t.erc721.TransferFrom(opts, owner, to, amount)
+	} else {
+		tx, err = t.erc20.TransferFrom(opts, owner, to, amount)
+	}
+
+	return tx, submitError(err)
+}
+
+// CheckTransferFrom checks that opts.From is allowed to spend amount of a
+// fungible token held by owner, or is approved to transfer the tokenID
+// amount of a non-fungible token owned by owner which is not paused
+func (t *Token) CheckTransferFrom(opts *bind.TransactOpts, owner common.Address, amount *big.Int) error {
+	if amount == nil || amount.Sign() < 0 {
+		return ErrInvalidAmount
+	}
+	spender := opts.From
+	callOpts := pendingCallOpts(opts.Context)
+
+	if t.Kind == NonFungible {
+		if err := t.CheckNotPaused(opts.Context); err != nil {
+			return err
+		}
+		tokenOwner, err := t.erc721.OwnerOf(callOpts, amount)
+		if err != nil {
+			return fmt.Errorf("OwnerOf: OwnerOf Error(%v)", err)
+		}
+		if tokenOwner != owner {
+			return fmt.Errorf("%w: the owner of tokenID(%s) is %s not %s",
+				ErrNotTokenOwner, amount.String(), tokenOwner.String(), owner.String())
+		}
+		if spender == owner {
+			return nil
+		}
+		approved, err := t.erc721.GetApproved(callOpts, amount)
+		if err != nil {
+			return fmt.Errorf("GetApproved: Get approved Error(%v)", err)
+		}
+		if approved == spender {
+			return nil
+		}
+		isOperator, err := t.erc721.IsApprovedForAll(callOpts, owner, spender)
+		if err != nil {
+			return fmt.Errorf("IsApprovedForAll: Get operator Error(%v)", err)
+		}
+		if !isOperator {
+			return fmt.Errorf("%w: %s is neither approved for tokenID(%s) nor operator of %s",
+				ErrNotApproved, spender.String(), amount.String(), owner.String())
+		}
+		return nil
+	}
+
+	if err := t.CheckTransferEnabled(opts.Context, owner); err != nil {
+		return err
+	}
+
+	return t.checkAllowance(callOpts, owner, spender, amount)
+}
+
+// checkAllowance checks that spender is allowed to spend amount of a
+// fungible token held by owner, and that owner holds amount
+func (t *Token) checkAllowance(callOpts *bind.CallOpts, owner, spender common.Address, amount *big.Int) error {
+	decimals, err := t.erc20.Decimals(callOpts)
+	if err != nil {
+		return fmt.Errorf("Decimals: Get decimals Error(%v)", err)
+	}
+	allowance, err := t.erc20.Allowance(callOpts, owner, spender)
+	if err != nil {
+		return fmt.Errorf("Allowance: Get allowance Error(%v)", err)
+	}
+	if allowance.Cmp(amount) < 0 {
+		return fmt.Errorf("%w: the allowance(%s) of %s is less than the amount(%s) of current transactions",
+			ErrInsufficientAllowance, FormatAmount(allowance, decimals), spender.String(), FormatAmount(amount, decimals))
+	}
+	balance, err := t.erc20.BalanceOf(callOpts, owner)
+	if err != nil {
+		return fmt.Errorf("Balance: BalanceOf Error(%v)", err)
+	}
+	if balance.Cmp(amount) < 0 {
+		return fmt.Errorf("%w: there is not enough balance(%s) of %s to pay the amount(%s) of current transactions",
+			ErrInsufficientBalance, FormatAmount(balance, decimals), owner.String(), FormatAmount(amount, decimals))
+	}
+
+	return nil
+}
+
+// SafeTransferFrom transfers the non-fungible tokenID from owner to to by
+// safeTransferFrom with data, opts.From should be the owner, approved or
+// operator of the owner. If to is a contract, the transfer is simulated
+// before signing to check that to accepts the token by onERC721Received.
+func (t *Token) SafeTransferFrom(opts *bind.TransactOpts, owner, to common.Address, tokenID *big.Int, data []byte) (*types.Transaction, error) {
+	if err := t.requireNonFungible(); err != nil {
+		return nil, err
+	}
+	if err := t.CheckTransferFrom(opts, owner, tokenID); err != nil {
+		return nil, err
+	}
+
+	code, err := t.backend.CodeAt(ensureContext(opts), to, nil)
+	if err != nil {
+		return nil, fmt.Errorf("CodeAt: Get code Error(%v)", err)
+	}
+	isContract := len(code) > 0
+
+	var tx *types.Transaction
+	if len(data) == 0 {
+		if isContract {
+			if err := t.simulate(opts, "safeTransferFrom", owner, to, tokenID); err != nil {
+				return nil, err
+			}
+		}
+		tx, err = t.erc721.SafeTransferFrom(opts, owner, to, tokenID)
+	} else {
+		if isContract {
+			if err := t.simulate(opts, "safeTransferFrom0", owner, to, tokenID, data); err != nil {
+				return nil, err
+			}
+		}
+		tx, err = t.erc721.SafeTransferFrom0(opts, owner, to, tokenID, data)
+	}
+
+	return tx, submitError(err)
+}
@@ -0,0 +1,73 @@
+package token
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// OperatorRole is the keccak256 hash of OPERATOR_ROLE, the accounts with
+// which can transfer before the transfer of NRC6|ERC20 token is enabled
+var OperatorRole = crypto.Keccak256Hash([]byte("OPERATOR_ROLE"))
+
+// TransferEnabled reports whether the transfer of a fungible token is enabled
+func (t *Token) TransferEnabled(ctx context.Context) (bool, error) {
+	if err := t.requireFungible(); err != nil {
+		return false, err
+	}
+	return t.erc20.TransferEnabled(callOpts(ctx))
+}
+
+// CheckTransferEnabled checks that holder can transfer a fungible token,
+// which is true once the transfer is enabled or if holder has the operator role
+func (t *Token) CheckTransferEnabled(ctx context.Context, holder common.Address) error {
+	if err := t.requireFungible(); err != nil {
+		return err
+	}
+	opts := pendingCallOpts(ctx)
+
+	enabled, err := t.erc20.TransferEnabled(opts)
+	if err != nil {
+		return fmt.Errorf("TransferEnabled: Get transfer enabled Error(%v)", err)
+	}
+	if enabled {
+		return nil
+	}
+	isOperator, err := t.erc20.HasRole(opts, OperatorRole, holder)
+	if err != nil {
+		return fmt.Errorf("HasRole: Check role Error(%v)", err)
+	}
+	if !isOperator {
+		return fmt.Errorf("%w: the transfer of the token is not enabled yet and %s has no OPERATOR role",
+			ErrTransferDisabled, holder.String())
+	}
+
+	return nil
+}
+
+// EnableTransfer enables the transfer of a fungible token permanently.
+// The ownership of opts.From and the transfer enabled flag are checked
+// before signing.
+func (t *Token) EnableTransfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	if err := t.requireFungible(); err != nil {
+		return nil, err
+	}
+	if err := t.checkOwner(opts); err != nil {
+		return nil, err
+	}
+
+	enabled, err := t.erc20.TransferEnabled(pendingCallOpts(opts.Context))
+	if err != nil {
+		return nil, fmt.Errorf("TransferEnabled: Get transfer enabled Error(%v)", err)
+	}
+	if enabled {
+		return nil, ErrTransferEnabled
+	}
+
+	tx, err := t.erc20.EnableTransfer(opts)
+	return tx, submitError(err)
+}
@@ -0,0 +1,76 @@
+package token
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// TokenURI returns the URI of a non-fungible tokenID
+func (t *Token) TokenURI(ctx context.Context, tokenID *big.Int) (string, error) {
+	if err := t.requireNonFungible(); err != nil {
+		return "", err
+	}
+	return t.erc721.TokenURI(callOpts(ctx), tokenID)
+}
+
+// Exists reports whether the non-fungible tokenID is minted and not burned
+func (t *Token) Exists(ctx context.Context, tokenID *big.Int) (bool, error) {
+	if err := t.requireNonFungible(); err != nil {
+		return false, err
+	}
+	return t.erc721.Exists(callOpts(ctx), tokenID)
+}
+
+// SetBaseURI sets the base URI of the tokenIDs of a non-fungible token.
+// The DEFAULT_ADMIN role of opts.From is checked before signing.
+func (t *Token) SetBaseURI(opts *bind.TransactOpts, baseURI string) (*types.Transaction, error) {
+	if err := t.requireNonFungible(); err != nil {
+		return nil, err
+	}
+
+	isAdmin, err := t.erc721.HasRole(pendingCallOpts(opts.Context), [32]byte{}, opts.From)
+	if err != nil {
+		return nil, fmt.Errorf("HasRole: Check role Error(%v)", err)
+	}
+	if !isAdmin {
+		return nil, fmt.Errorf("%w: the from address(%s) has no %s role to set base URI",
+			ErrNoRole, opts.From.String(), RoleDefaultAdmin)
+	}
+
+	tx, err := t.erc721.SetBaseURI(opts, baseURI)
+	return tx, submitError(err)
+}
+
+// SetTokenURI sets the URI of the non-fungible tokenID.
+// The minter role of opts.From and the existence of tokenID are checked
+// before signing.
+func (t *Token) SetTokenURI(opts *bind.TransactOpts, tokenID *big.Int, tokenURI string) (*types.Transaction, error) {
+	if err := t.requireNonFungible(); err != nil {
+		return nil, err
+	}
+	if tokenID == nil || tokenID.Sign() < 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	isMinter, err := t.IsMinter(opts.Context, opts.From)
+	if err != nil {
+		return nil, fmt.Errorf("check minter error(%v)", err)
+	}
+	if !isMinter {
+		return nil, fmt.Errorf("%w: the from address(%s) is not minter", ErrNotMinter, opts.From.String())
+	}
+	exists, err := t.erc721.Exists(pendingCallOpts(opts.Context), tokenID)
+	if err != nil {
+		return nil, fmt.Errorf("Exists: Check token ID exists Error(%v)", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w: tokenID(%s)", ErrTokenNotExists, tokenID.String())
+	}
+
+	tx, err := t.erc721.SetTokenURI(opts, tokenID, tokenURI)
+	return tx, submitError(err)
+}